@@ -1,227 +1,211 @@
 package main
 
 import (
-	"encoding/csv"
+	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"math"
-	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/souza/esw-008/ml-nb-model/internal/augment"
+	"github.com/souza/esw-008/ml-nb-model/internal/calibration"
 	"github.com/souza/esw-008/ml-nb-model/internal/crawler"
+	"github.com/souza/esw-008/ml-nb-model/internal/dataset"
+	"github.com/souza/esw-008/ml-nb-model/internal/decision"
+	"github.com/souza/esw-008/ml-nb-model/internal/embeddings"
+	"github.com/souza/esw-008/ml-nb-model/internal/ensemble"
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+	"github.com/souza/esw-008/ml-nb-model/internal/explain"
+	"github.com/souza/esw-008/ml-nb-model/internal/heuristic"
+	"github.com/souza/esw-008/ml-nb-model/internal/input"
+	"github.com/souza/esw-008/ml-nb-model/internal/linear"
 	"github.com/souza/esw-008/ml-nb-model/internal/mlp"
 	"github.com/souza/esw-008/ml-nb-model/internal/models"
-	"github.com/souza/esw-008/ml-nb-model/internal/naivebayes"
+	"github.com/souza/esw-008/ml-nb-model/internal/neardup"
+	"github.com/souza/esw-008/ml-nb-model/internal/neighbors"
+	"github.com/souza/esw-008/ml-nb-model/internal/reputation"
+	"github.com/souza/esw-008/ml-nb-model/internal/retrieval"
+	"github.com/souza/esw-008/ml-nb-model/internal/segment"
+	"github.com/souza/esw-008/ml-nb-model/internal/selection"
+	"github.com/souza/esw-008/ml-nb-model/internal/stats"
+	"github.com/souza/esw-008/ml-nb-model/internal/stylometry"
+	"github.com/souza/esw-008/ml-nb-model/internal/topics"
+	"github.com/souza/esw-008/ml-nb-model/internal/tuning"
 	"github.com/souza/esw-008/ml-nb-model/internal/utils"
 )
 
-// loadDatasetFromURL carrega o dataset de uma URL
-func loadDatasetFromURL(url string) ([]models.NewsRecord, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	return parseDataset(resp.Body)
-}
+// evaluateModel avalia um modelo usando 5-fold cross-validation (um treinamento por fold).
+// Com --calibration, as predições out-of-fold são calibradas fold a fold antes das métricas.
+func evaluateModel(docs []models.Document, algorithm string) models.Metrics {
+	fmt.Printf("Avaliando modelo %s com 5-fold cross-validation...\n", algorithm)
 
-// parseDataset parseia o dataset CSV
-func parseDataset(r io.Reader) ([]models.NewsRecord, error) {
-	reader := csv.NewReader(r)
-	reader.Comma = ','
+	predictions := evaluation.CrossValidate(docs, cvFactory(algorithm), 5, func(fold, total int) {
+		fmt.Printf("Fold %d/%d\n", fold, total)
+	})
+	if sourcePrior {
+		predictions = reputation.CrossApply(docs, predictions, 5, reputationOptions, reputationList)
+	}
 
-	// Pular cabeçalho
-	_, err := reader.Read()
+	calibrated, err := calibration.CrossCalibrate(calibrationMethod, predictions)
 	if err != nil {
-		return nil, err
+		log.Fatalf("Erro na calibração: %v", err)
 	}
+	return evaluation.Evaluate(calibrated, 10)
+}
 
-	var records []models.NewsRecord
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
+// cvFactory cria a fábrica de classificadores usada em cross-validation
+func cvFactory(algorithm string) models.Factory {
+	// Reduzir épocas para cross-validation (mais rápido)
+	return baseFactory(algorithm, 10)
+}
+
+// hyperparameters são os hiperparâmetros de cada algoritmo, lidos de --config
+// (gerado pelo comando tune) e sobrepostos por --penalty e --lambda
+var hyperparameters = tuning.NewConfig()
+
+// baseFactory cria a fábrica do algoritmo ("MLP", "Naive Bayes", "Regressão Logística",
+// "SVM Linear" ou "Ensemble") com os hiperparâmetros configurados;
+// mlpEpochs > 0 substitui o número de épocas do MLP quando não configurado
+func baseFactory(algorithm string, mlpEpochs int) models.Factory {
+	if algorithm == "Ensemble" {
+		// Os membros são criados (e validados) uma vez, só quando o ensemble é usado
+		members := ensembleMembers(mlpEpochs)
+		if _, err := ensemble.NewClassifier(ensembleMethod, members); err != nil {
+			log.Fatalf("Erro: %v", err)
 		}
-		if err != nil {
-			return nil, err
+		return func() models.Classifier {
+			classifier, _ := ensemble.NewClassifier(ensembleMethod, members)
+			return classifier
 		}
+	}
 
-		if len(record) >= 5 {
-			newsRecord := models.NewsRecord{
-				TitleFake: record[0],
-				FakeText:  record[1],
-				LinkFake:  record[2],
-				TrueText:  record[3],
-				LinkTrue:  record[4],
-			}
-			records = append(records, newsRecord)
-		}
+	tunable, ok := tuning.Lookup(algorithm)
+	if !ok {
+		tunable, _ = tuning.Lookup("Naive Bayes")
+	}
+	params := hyperparameters.Params(tunable.Name)
+	if tunable.Name == "MLP" && mlpEpochs > 0 && params["epochs"] == "" {
+		params = params.Merge(tuning.Params{"epochs": strconv.Itoa(mlpEpochs)})
 	}
 
-	return records, nil
+	factory, err := tunable.Factory(params)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+	if augmentOptions.Copies > 0 {
+		factory = augment.Factory(factory, augmentOptions, thesaurus)
+	}
+	return factory
 }
 
-// createFolds cria os folds para cross-validation
-func createFolds(records []models.NewsRecord, numFolds int) []models.Fold {
-	folds := make([]models.Fold, numFolds)
-
-	// Dividir registros em folds
-	for i, record := range records {
-		foldIndex := i % numFolds
-		folds[foldIndex].Test = append(folds[foldIndex].Test, record)
+// configureHyperparameters carrega o arquivo de --config (se informado) e aplica
+// as flags de hiperparâmetros usadas (--penalty e --lambda nos modelos lineares,
+// --mlp-input e --embedding-* no MLP) aos algoritmos que têm o parâmetro
+func configureHyperparameters(path string, overrides tuning.Params) {
+	hyperparameterFlags = overrides
+	if path != "" {
+		config, err := tuning.LoadConfig(path)
+		if err != nil {
+			log.Fatalf("Erro ao carregar configuração: %v", err)
+		}
+		hyperparameters = config
 	}
 
-	// Para cada fold, usar os outros como treinamento
-	for i := range folds {
-		for j, fold := range folds {
-			if j != i {
-				folds[i].Train = append(folds[i].Train, fold.Test...)
+	for _, tunable := range tuning.Algorithms {
+		algorithm := tunable.Name
+		entry := hyperparameters.Algorithms[algorithm]
+		for name, value := range overrides {
+			if _, ok := tunable.Defaults[name]; ok {
+				entry.Params = entry.Params.Merge(tuning.Params{name: value})
 			}
 		}
+		if err := tunable.Validate(entry.Params); err != nil {
+			log.Fatalf("Erro: %v", err)
+		}
+		hyperparameters.Algorithms[algorithm] = entry
 	}
-
-	return folds
 }
 
-// calculateMetrics calcula métricas de avaliação
-func calculateMetrics(predictions, actuals []string) models.Metrics {
-	var tp, fp, tn, fn int
+// hyperparameterFlags são os hiperparâmetros definidos pelas flags globais (--stylometry,
+// --hashing, --selection...), que o comando tune mantém fixos durante a busca
+var hyperparameterFlags tuning.Params
 
-	for i, pred := range predictions {
-		actual := actuals[i]
-		if pred == "true" && actual == "true" {
-			tp++
-		} else if pred == "true" && actual == "fake" {
-			fp++
-		} else if pred == "fake" && actual == "true" {
-			fn++
-		} else if pred == "fake" && actual == "fake" {
-			tn++
-		}
-	}
+// ensembleMethod é o método de combinação do ensemble, definido pela flag --ensemble
+var ensembleMethod = ensemble.MethodWeighted
 
-	accuracy := float64(tp+tn) / float64(tp+tn+fp+fn)
-	precision := 0.0
-	if tp+fp > 0 {
-		precision = float64(tp) / float64(tp+fp)
-	}
-	recall := 0.0
-	if tp+fn > 0 {
-		recall = float64(tp) / float64(tp+fn)
-	}
-	f1Score := 0.0
-	if precision+recall > 0 {
-		f1Score = 2 * (precision * recall) / (precision + recall)
-	}
+// ensembleWeights são os pesos fixos dos membros (nb, mlp, heuristic), definidos por --ensemble-weight
+var ensembleWeights map[string]float64
+
+// ensembleWeightKeys são os nomes dos membros aceitos por --ensemble-weight
+var ensembleWeightKeys = []string{"nb", "mlp", "heuristic"}
 
-	return models.Metrics{
-		Accuracy:  accuracy,
-		Precision: precision,
-		Recall:    recall,
-		F1Score:   f1Score,
+// ensembleMembers retorna os membros do ensemble: Naive Bayes, MLP e a heurística de termos
+func ensembleMembers(mlpEpochs int) []ensemble.Member {
+	return []ensemble.Member{
+		{Name: "Naive Bayes", Factory: baseFactory("Naive Bayes", mlpEpochs), Weight: ensembleWeights["nb"]},
+		{Name: "MLP", Factory: baseFactory("MLP", mlpEpochs), Weight: ensembleWeights["mlp"]},
+		{Name: "Heurística", Factory: func() models.Classifier { return heuristic.NewClassifier(reputationOptions.Untrusted) }, Weight: ensembleWeights["heuristic"]},
 	}
 }
 
-// evaluateModel avalia um modelo usando cross-validation
-func evaluateModel(records []models.NewsRecord, algorithm string) models.Metrics {
-	fmt.Printf("Avaliando modelo %s com 5-fold cross-validation...\n", algorithm)
+// datasetSource é a URL, o arquivo ou o diretório do dataset de treinamento, e
+// datasetFormat o seu formato (flags --dataset e --dataset-format)
+var (
+	datasetSource = dataset.DefaultURL
+	datasetFormat = dataset.FormatAuto
+)
 
-	folds := createFolds(records, 5)
-	var totalTP, totalFP, totalFN, totalTN int
-
-	for i, fold := range folds {
-		fmt.Printf("Fold %d/%d\n", i+1, 5)
-
-		var predictions []string
-		var trueLabels []string
-
-		// Preparar dados de teste
-		for _, record := range fold.Test {
-			// Para cada registro, testar tanto o texto falso quanto o verdadeiro
-			if strings.TrimSpace(record.FakeText) != "" {
-				trueLabels = append(trueLabels, "fake")
-				if algorithm == "MLP" {
-					classifier := mlp.NewClassifier(1000, 50, 2)
-					// Reduzir épocas para cross-validation (mais rápido)
-					classifier.Epochs = 10
-					classifier.Train(fold.Train)
-					pred, _ := classifier.Classify(record.FakeText)
-					predictions = append(predictions, pred)
-				} else {
-					classifier := naivebayes.NewClassifier()
-					classifier.TrainNB(fold.Train)
-					pred, _ := classifier.ClassifyNB(record.FakeText)
-					predictions = append(predictions, pred)
-				}
-			}
+// duplicateOptions configura a detecção de quase-duplicados (flag --dup-threshold),
+// e groupDuplicates (flag --group-duplicates) põe quase-duplicados no mesmo fold
+var (
+	duplicateOptions = neardup.DefaultOptions()
+	groupDuplicates  bool
+)
 
-			if strings.TrimSpace(record.TrueText) != "" {
-				trueLabels = append(trueLabels, "true")
-				if algorithm == "MLP" {
-					classifier := mlp.NewClassifier(1000, 50, 2)
-					// Reduzir épocas para cross-validation (mais rápido)
-					classifier.Epochs = 10
-					classifier.Train(fold.Train)
-					pred, _ := classifier.Classify(record.TrueText)
-					predictions = append(predictions, pred)
-				} else {
-					classifier := naivebayes.NewClassifier()
-					classifier.TrainNB(fold.Train)
-					pred, _ := classifier.ClassifyNB(record.TrueText)
-					predictions = append(predictions, pred)
-				}
-			}
-		}
+// retrievalOptions configura o índice de notícias semelhantes (flag --retrieval), e
+// similarCount (flag --similar) é o número de notícias semelhantes exibidas por classe
+var (
+	retrievalOptions = retrieval.DefaultOptions()
+	similarCount     = 3
+)
 
-		// Calcular métricas para este fold
-		for j, pred := range predictions {
-			trueLabel := trueLabels[j]
-			if pred == "true" && trueLabel == "true" {
-				totalTP++
-			} else if pred == "true" && trueLabel == "fake" {
-				totalFP++
-			} else if pred == "fake" && trueLabel == "true" {
-				totalFN++
-			} else if pred == "fake" && trueLabel == "fake" {
-				totalTN++
-			}
-		}
-	}
+// reputationOptions e reputationList configuram o prior de fonte (flags --source-weight e
+// --source-list); sourcePrior (--source-prior) combina o prior com os modelos de texto, e
+// sourceURL (--source-url) é o link da notícia quando ela vem de um arquivo ou do stdin
+var (
+	reputationOptions = reputation.DefaultOptions()
+	reputationList    *reputation.List
+	sourcePrior       bool
+	sourceURL         string
+)
 
-	// Calcular métricas finais
-	accuracy := float64(totalTP+totalTN) / float64(totalTP+totalTN+totalFP+totalFN)
-	precision := 0.0
-	if totalTP+totalFP > 0 {
-		precision = float64(totalTP) / float64(totalTP+totalFP)
-	}
-	recall := 0.0
-	if totalTP+totalFN > 0 {
-		recall = float64(totalTP) / float64(totalTP+totalFN)
-	}
-	f1Score := 0.0
-	if precision+recall > 0 {
-		f1Score = 2 * (precision * recall) / (precision + recall)
-	}
+// augmentOptions configura o aumento dos documentos de treinamento (flags --augment,
+// --augment-strength e --augment-ops), e thesaurus é o dicionário de sinônimos de --thesaurus
+var (
+	augmentOptions = augment.DefaultOptions()
+	thesaurus      augment.Thesaurus
+)
 
-	return models.Metrics{
-		Accuracy:  accuracy,
-		Precision: precision,
-		Recall:    recall,
-		F1Score:   f1Score,
-	}
-}
+// inputFormat é o formato da entrada (auto, html, text ou pdf), definido pela flag --format
+var inputFormat = input.FormatAuto
 
-// classifyNews classifica uma notícia usando o algoritmo especificado
-func classifyNews(url string, algorithm string) {
-	fmt.Printf("Analisando a URL: %s\n", url)
+// classifyNews classifica uma notícia (URL, arquivo ou stdin) usando o algoritmo
+// especificado, treinado com os documentos do dataset já carregado
+func classifyNews(source string, algorithm string, docs []models.Document) {
+	fmt.Printf("Analisando: %s\n", source)
 
-	articleText, err := crawler.CrawlNews(url)
+	content, err := input.Load(source, inputFormat)
 	if err != nil {
 		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
 	}
+	articleText := content.Text
 
 	if strings.TrimSpace(articleText) == "" {
 		fmt.Println("Não foi possível extrair texto relevante da página.")
@@ -233,71 +217,184 @@ func classifyNews(url string, algorithm string) {
 	}
 	fmt.Printf("Texto extraído (%d caracteres): %s...\n\n", len(articleText), articleText[:utils.Min(200, len(articleText))])
 
-	// Carregar dataset para treinamento
-	datasetURL := "https://raw.githubusercontent.com/jpchav98/FakeTrue.Br/refs/heads/main/FakeTrueBr_corpus.csv"
-	records, err := loadDatasetFromURL(datasetURL)
-	if err != nil {
-		log.Fatalf("Falha ao carregar o dataset: %v", err)
+	fmt.Printf("Treinando classificador %s...\n", algorithm)
+	classifier := newClassifier(algorithm)
+	classifier.Train(docs)
+	prediction := classifier.Predict(articleText)
+	sources := sourceModel(docs)
+	prior := sources.Prior(articleLink(content))
+	if sourcePrior {
+		prediction = sources.Combine(prediction, prior)
 	}
+	probs := prediction.Probabilities
 
-	var label string
-	var confidence float64
-	var probs map[string]float64
-	var topTokens []string
+	// Aplicar a política de decisão (limiares, custos e veredito inconclusivo)
+	verdict := decisionPolicy.Decide(probs)
+	result := displayLabel(verdict.Label)
 
-	if algorithm == "MLP" {
-		fmt.Println("Treinando classificador MLP...")
-		classifier := mlp.NewClassifier(1000, 50, 2)
-		classifier.Train(records)
-		label, confidence, probs, topTokens = classifier.ClassifyWithDebug(articleText)
-	} else {
-		fmt.Println("Treinando classificador Naive Bayes...")
-		classifier := naivebayes.NewClassifier()
-		classifier.TrainNB(records)
-		label, confidence, probs, topTokens = classifier.ClassifyWithDebugNB(articleText)
+	// O ensemble já incorpora a heurística como um de seus membros
+	if algorithm != "Ensemble" && heuristic.Applies(articleText, reputationOptions.Untrusted, models.Labels(docs)) {
+		printHeuristicVerdict()
+		return
 	}
 
-	// Map the label for better display
-	var result string
-	if label == "true" {
-		result = "Provavelmente Verdadeira"
-	} else {
-		result = "Provavelmente Falsa"
+	fmt.Println("--- Resultado da Análise ---")
+	fmt.Printf("Algoritmo utilizado: %s\n", algorithm)
+	fmt.Printf("Classificação: %s\n", result)
+	if verdict.Inconclusive() {
+		fmt.Printf("Motivo: %s (classe mais provável: %s)\n", verdict.Reason, displayLabel(verdict.Candidate))
+	}
+	fmt.Printf("Confiança: %.2f%%\n", verdict.Probability)
+	fmt.Printf("Probabilidades: %s\n", formatProbabilities(probs))
+	if raw := prediction.RawProbabilities; raw != nil {
+		fmt.Printf("Probabilidades sem calibração (%s): %s\n", calibrationMethod, formatProbabilities(raw))
+	}
+	fmt.Println("Tokens mais influentes para a decisão:")
+	printContributions(prediction.Contributions, 10)
+	fmt.Printf("Fonte analisada: %s (%s)\n", content.Origin, content.Format)
+	printSourceReputation(prior)
+	printNearCopies(docs, articleText)
+	printSimilarArticles(docs, articleText)
+	fmt.Println("----------------------------")
+}
+
+// sourceModel treina o modelo de reputação de fontes com os links dos documentos
+func sourceModel(docs []models.Document) *reputation.Model {
+	model := reputation.New(reputationOptions, reputationList)
+	model.Train(docs)
+	return model
+}
+
+// articleLink retorna o link da notícia analisada: --source-url ou a URL de origem
+// ("" para arquivos e stdin)
+func articleLink(content *input.Content) string {
+	if sourceURL != "" {
+		return sourceURL
+	}
+	if strings.Contains(content.Origin, "://") {
+		return content.Origin
+	}
+	return ""
+}
+
+// printSourceReputation imprime a evidência do domínio da notícia: a entrada da lista
+// de reputação ou os rótulos das notícias do mesmo domínio no corpus
+func printSourceReputation(prior reputation.Prior) {
+	if prior.Domain == "" {
+		return
+	}
+	switch {
+	case prior.Listed != "":
+		fmt.Printf("Reputação da fonte: %s está na lista de reputação (%s)", prior.Matched, prior.Listed)
+	case prior.Known():
+		var counts []string
+		for _, class := range orderedClasses(prior.Probabilities) {
+			counts = append(counts, fmt.Sprintf("%s %d", classLabel(class), prior.Counts[class]))
+		}
+		fmt.Printf("Reputação da fonte: %d notícias de %s no corpus (%s)", prior.Total, prior.Matched, strings.Join(counts, ", "))
+	default:
+		fmt.Printf("Reputação da fonte: %s não aparece no corpus nem na lista de reputação\n", prior.Domain)
+		return
+	}
+	probs := make(map[string]float64)
+	for class, p := range prior.Probabilities {
+		probs[class] = p * 100
+	}
+	fmt.Printf("; P(classe | fonte): %s\n", formatProbabilities(probs))
+	if !sourcePrior {
+		fmt.Println("  (use --source-prior para combinar a reputação da fonte com o modelo de texto)")
+	}
+}
+
+// printSimilarArticles imprime as notícias do corpus mais semelhantes ao texto,
+// separadas por classe, como evidência de apoio à classificação
+func printSimilarArticles(docs []models.Document, text string) {
+	if similarCount <= 0 {
+		return
+	}
+	index, err := retrieval.NewIndex(docs, retrievalOptions)
+	if err != nil {
+		log.Fatalf("Erro no índice de notícias semelhantes: %v", err)
 	}
 
-	lowerText := strings.ToLower(articleText)
-	if strings.Contains(lowerText, "boato") || strings.Contains(lowerText, "falso") ||
-		strings.Contains(lowerText, "mentira") || strings.Contains(lowerText, "desmentido") ||
-		strings.Contains(lowerText, "fake news") {
-		fmt.Println("[HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
-		fmt.Println("--- Resultado da Análise ---")
-		fmt.Println("Classificação: Provavelmente Falsa (por heurística)")
-		fmt.Println("----------------------------")
+	byLabel := index.SearchByLabel(text, similarCount)
+	if len(byLabel) == 0 {
 		return
 	}
+	fmt.Printf("Notícias semelhantes no corpus (%s):\n", retrievalOptions.Scoring)
+	for _, label := range orderedClasses(byLabel) {
+		fmt.Printf("  %s:\n", classLabel(label))
+		for _, hit := range byLabel[label] {
+			fmt.Printf("    %.4f  %s\n", hit.Score, articleReference(hit.Document))
+			if len(hit.Terms) > 0 {
+				fmt.Printf("            termos em comum: %s\n", strings.Join(hit.Terms, ", "))
+			}
+		}
+	}
+}
+
+// articleReference identifica um documento do corpus pelo título e link (ou pelo id)
+func articleReference(doc models.Document) string {
+	reference := strings.TrimSpace(doc.Title + " " + doc.URL)
+	if reference == "" {
+		reference = doc.ID
+	}
+	return reference
+}
+
+// printNearCopies avisa quando o texto é uma quase-cópia de documentos do corpus,
+// mostrando o rótulo conhecido de cada um
+func printNearCopies(docs []models.Document, text string) {
+	index, err := neardup.IndexDocuments(docs, duplicateOptions)
+	if err != nil {
+		log.Fatalf("Erro na detecção de quase-duplicados: %v", err)
+	}
+	matches := index.Query(text)
+	for i, match := range matches {
+		if i >= 3 {
+			fmt.Printf("... e mais %d quase-cópias no corpus\n", len(matches)-i)
+			break
+		}
+		doc := docs[match.Index]
+		fmt.Printf("[QUASE-CÓPIA] O texto é uma quase-cópia de uma notícia rotulada como %s no corpus (Jaccard %.2f): %s\n",
+			classLabel(doc.Label), match.Similarity, articleReference(doc))
+	}
+}
 
+// comparedAlgorithms são os algoritmos individuais das comparações, na ordem das tabelas
+var comparedAlgorithms = []string{"MLP", "Naive Bayes", "Regressão Logística", "SVM Linear", "kNN", "Centroide"}
+
+// algorithmResult guarda a análise de um algoritmo na comparação
+type algorithmResult struct {
+	Name       string
+	Prediction models.ClassificationResult
+	Decision   decision.Decision
+	Metrics    models.Metrics
+}
+
+// printHeuristicVerdict imprime o resultado decidido pela heurística de termos
+func printHeuristicVerdict() {
+	fmt.Println("[HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
 	fmt.Println("--- Resultado da Análise ---")
-	fmt.Printf("Algoritmo utilizado: %s\n", algorithm)
-	fmt.Printf("Classificação: %s\n", result)
-	fmt.Printf("Confiança: %.2f%%\n", confidence)
-	fmt.Printf("Probabilidades: Verdadeira: %.2f%% | Falsa: %.2f%%\n", probs["true"], probs["fake"])
-	fmt.Printf("Tokens mais influentes para a decisão: %v\n", topTokens)
-	fmt.Printf("URL analisada: %s\n", url)
+	fmt.Printf("Classificação: %s (por heurística)\n", displayLabel(reputationOptions.Untrusted))
 	fmt.Println("----------------------------")
 }
 
-// compareAlgorithms compara MLP e Naive Bayes em uma URL específica
-func compareAlgorithms(url string, records []models.NewsRecord) {
-	fmt.Printf("Analisando a URL: %s\n", url)
+// loadArticle carrega o texto da notícia para as comparações; retorna nil quando
+// não há texto ou quando a heurística de termos já decide o resultado (apenas se o
+// rótulo de --untrusted-label estiver entre as classes do treinamento)
+func loadArticle(source string, docs []models.Document) *input.Content {
+	fmt.Printf("Analisando: %s\n", source)
 
-	articleText, err := crawler.CrawlNews(url)
+	content, err := input.Load(source, inputFormat)
 	if err != nil {
 		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
 	}
+	articleText := content.Text
 
 	if strings.TrimSpace(articleText) == "" {
 		fmt.Println("Não foi possível extrair texto relevante da página.")
-		return
+		return nil
 	}
 
 	if len(articleText) < 300 {
@@ -306,98 +403,150 @@ func compareAlgorithms(url string, records []models.NewsRecord) {
 	fmt.Printf("Texto extraído (%d caracteres): %s...\n\n", len(articleText), articleText[:utils.Min(200, len(articleText))])
 
 	// Verificar heurística
-	lowerText := strings.ToLower(articleText)
-	if strings.Contains(lowerText, "boato") || strings.Contains(lowerText, "falso") ||
-		strings.Contains(lowerText, "mentira") || strings.Contains(lowerText, "desmentido") ||
-		strings.Contains(lowerText, "fake news") {
-		fmt.Println("[HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
-		fmt.Println("--- Resultado da Análise ---")
-		fmt.Println("Classificação: Provavelmente Falsa (por heurística)")
-		fmt.Println("----------------------------")
-		return
+	if heuristic.Applies(articleText, reputationOptions.Untrusted, models.Labels(docs)) {
+		printHeuristicVerdict()
+		return nil
 	}
+	return content
+}
 
-	// Calcular métricas de cross-validation primeiro
-	fmt.Println("=== CALCULANDO MÉTRICAS DE PERFORMANCE ===")
-	fmt.Println("Executando 5-fold cross-validation...")
-	fmt.Println("(Isso pode levar alguns minutos devido ao treinamento do MLP)")
+// analyzeWithAlgorithms treina cada algoritmo com todos os documentos e classifica o texto
+// (com --source-prior, combinado com o prior do domínio do link)
+func analyzeWithAlgorithms(algorithms []string, docs []models.Document, text string, link string) []algorithmResult {
+	sources := sourceModel(docs)
+	prior := sources.Prior(link)
+	var results []algorithmResult
+	var index *retrieval.Index // índice TF-IDF compartilhado entre kNN e centroide
+	for _, algorithm := range algorithms {
+		fmt.Printf("\n=== ANÁLISE COM %s ===\n", strings.ToUpper(algorithmLabel(algorithm)))
+		classifier := newClassifier(algorithm)
+		if sharesIndex(algorithm) {
+			if index == nil {
+				index = neighbors.NewIndex(docs)
+			}
+			classifier = neighborsClassifier(algorithm, index)
+		}
+		classifier.Train(docs)
+		prediction := classifier.Predict(text)
+		if sourcePrior {
+			prediction = sources.Combine(prediction, prior)
+		}
+		results = append(results, algorithmResult{
+			Name:       algorithm,
+			Prediction: prediction,
+			Decision:   decisionPolicy.Decide(prediction.Probabilities),
+		})
+	}
+	return results
+}
 
-	mlpMetrics := evaluateModel(records, "MLP")
-	nbMetrics := evaluateModel(records, "Naive Bayes")
-
-	// Treinar e testar MLP
-	fmt.Println("\n=== ANÁLISE COM MLP ===")
-	mlpClassifier := mlp.NewClassifier(1000, 50, 2)
-	mlpClassifier.Train(records)
-	mlpLabel, mlpConfidence, mlpProbs, mlpTokens := mlpClassifier.ClassifyWithDebug(articleText)
-
-	// Treinar e testar Naive Bayes
-	fmt.Println("\n=== ANÁLISE COM NAIVE BAYES ===")
-	nbClassifier := naivebayes.NewClassifier()
-	nbClassifier.TrainNB(records)
-	nbLabel, nbConfidence, nbProbs, nbTokens := nbClassifier.ClassifyWithDebugNB(articleText)
-
-	// Mapear labels para exibição
-	var mlpResult, nbResult string
-	if mlpLabel == "true" {
-		mlpResult = "Provavelmente Verdadeira"
-	} else {
-		mlpResult = "Provavelmente Falsa"
+// sharesIndex indica se o algoritmo pode usar o índice TF-IDF construído uma vez com
+// todos os documentos; com calibração ou aumento de dados os documentos de treinamento
+// mudam, e cada modelo indexa os próprios
+func sharesIndex(algorithm string) bool {
+	return (algorithm == "kNN" || algorithm == "Centroide") &&
+		calibrationMethod == calibration.MethodNone && augmentOptions.Copies == 0
+}
+
+// neighborsClassifier cria o kNN ou o centroide configurado com o índice já construído
+func neighborsClassifier(algorithm string, index *retrieval.Index) models.Classifier {
+	tunable, _ := tuning.Lookup(algorithm)
+	classifier, err := tuning.NeighborsWithIndex(algorithm, tunable.Defaults.Merge(hyperparameters.Params(algorithm)), index)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
 	}
+	return classifier
+}
 
-	if nbLabel == "true" {
-		nbResult = "Provavelmente Verdadeira"
-	} else {
-		nbResult = "Provavelmente Falsa"
+// checkClass encerra com erro se a classe informada na opção --name não é uma das
+// classes do dataset de treinamento
+func checkClass(name, class string, docs []models.Document) {
+	classes := models.Labels(docs)
+	for _, label := range classes {
+		if label == class {
+			return
+		}
 	}
+	log.Fatalf("Erro: classe %q desconhecida em --%s (classes do modelo: %s)", class, name, strings.Join(classes, ", "))
+}
 
-	// Imprimir comparação
-	fmt.Println("\n" + strings.Repeat("=", 120))
-	fmt.Println("COMPARAÇÃO ENTRE ALGORITMOS")
-	fmt.Println(strings.Repeat("=", 120))
-	fmt.Printf("URL analisada: %s\n\n", url)
+// algorithmLabel retorna o nome do algoritmo para exibição (com o método, no caso do ensemble)
+func algorithmLabel(algorithm string) string {
+	if algorithm == "Ensemble" {
+		return fmt.Sprintf("Ensemble (%s)", ensembleMethod)
+	}
+	return algorithm
+}
 
-	// Tabela de resultados com métricas de cross-validation
-	fmt.Printf("%-20s %-25s %-15s %-20s %-12s %-12s %-12s %-12s\n",
-		"Algoritmo", "Classificação", "Confiança", "Probabilidades", "Acurácia", "Precisão", "Revocação", "F1-Score")
-	fmt.Println(strings.Repeat("-", 120))
-	fmt.Printf("%-20s %-25s %-15.2f%% %-20s %-12.4f %-12.4f %-12.4f %-12.4f\n",
-		"MLP", mlpResult, mlpConfidence,
-		fmt.Sprintf("V:%.1f%% F:%.1f%%", mlpProbs["true"], mlpProbs["fake"]),
-		mlpMetrics.Accuracy, mlpMetrics.Precision, mlpMetrics.Recall, mlpMetrics.F1Score)
-	fmt.Printf("%-20s %-25s %-15.2f%% %-20s %-12.4f %-12.4f %-12.4f %-12.4f\n",
-		"Naive Bayes", nbResult, nbConfidence,
-		fmt.Sprintf("V:%.1f%% F:%.1f%%", nbProbs["true"], nbProbs["fake"]),
-		nbMetrics.Accuracy, nbMetrics.Precision, nbMetrics.Recall, nbMetrics.F1Score)
-	fmt.Println(strings.Repeat("=", 120))
+// printComparisonTable imprime a tabela de resultados (com métricas de cross-validation, se houver)
+func printComparisonTable(results []algorithmResult, withMetrics bool) {
+	if withMetrics {
+		fmt.Printf("%-22s %-25s %-15s %-20s %-12s %-12s %-12s %-12s\n",
+			"Algoritmo", "Classificação", "Confiança", "Probabilidades", "Acurácia", "Precisão", "Revocação", "F1-Score")
+		fmt.Println(strings.Repeat("-", 120))
+	} else {
+		fmt.Printf("%-22s %-25s %-15s %-20s\n", "Algoritmo", "Classificação", "Confiança", "Probabilidades")
+		fmt.Println(strings.Repeat("-", 80))
+	}
 
-	// Detalhes dos tokens influentes
-	fmt.Println("\n=== TOKENS MAIS INFLUENTES ===")
-	fmt.Println("MLP:")
-	for i, token := range mlpTokens {
-		if i < 5 {
-			fmt.Printf("  %s\n", token)
+	for _, r := range results {
+		probs := r.Prediction.Probabilities
+		fmt.Printf("%-22s %-25s %-15.2f%% %-20s", algorithmLabel(r.Name), displayLabel(r.Decision.Label),
+			r.Decision.Probability, compactProbabilities(probs))
+		if withMetrics {
+			fmt.Printf(" %-12.4f %-12.4f %-12.4f %-12.4f",
+				r.Metrics.Accuracy, r.Metrics.Precision, r.Metrics.Recall, r.Metrics.F1Score)
 		}
+		fmt.Println()
 	}
-	fmt.Println("\nNaive Bayes:")
-	for i, token := range nbTokens {
-		if i < 5 {
-			fmt.Printf("  %s\n", token)
+}
+
+// printAgreement imprime a concordância e a divergência de confiança entre os
+// algoritmos individuais e, se presente, o veredito combinado do ensemble
+func printAgreement(results []algorithmResult) {
+	fmt.Println("\n=== ANÁLISE DE CONCORDÂNCIA ===")
+
+	var individual []algorithmResult
+	var combined *algorithmResult
+	inconclusive := false
+	labels := make(map[string]bool)
+	for i, r := range results {
+		if r.Name == "Ensemble" {
+			combined = &results[i]
+			continue
+		}
+		individual = append(individual, r)
+		labels[r.Decision.Label] = true
+		if r.Decision.Inconclusive() {
+			inconclusive = true
 		}
 	}
 
-	// Análise de concordância
-	fmt.Println("\n=== ANÁLISE DE CONCORDÂNCIA ===")
-	if mlpLabel == nbLabel {
-		fmt.Printf("✅ Os algoritmos concordam: %s\n", mlpResult)
+	if inconclusive {
+		fmt.Printf("⚠️  Resultado inconclusivo:\n")
+		for _, r := range individual {
+			printDecision(r.Name, r.Decision)
+		}
+	} else if len(labels) == 1 {
+		fmt.Printf("✅ Os algoritmos concordam: %s\n", displayLabel(individual[0].Decision.Label))
 	} else {
 		fmt.Printf("❌ Os algoritmos discordam:\n")
-		fmt.Printf("   MLP: %s (%.2f%%)\n", mlpResult, mlpConfidence)
-		fmt.Printf("   NB:  %s (%.2f%%)\n", nbResult, nbConfidence)
+		for _, r := range individual {
+			printDecision(r.Name, r.Decision)
+		}
+	}
+	if combined != nil {
+		fmt.Printf("Veredito combinado do %s: %s (%.2f%%)\n",
+			algorithmLabel(combined.Name), displayLabel(combined.Decision.Label), combined.Decision.Probability)
 	}
 
-	// Diferença de confiança
-	confidenceDiff := math.Abs(mlpConfidence - nbConfidence)
+	// Diferença de confiança (maior diferença entre os algoritmos individuais)
+	minConfidence, maxConfidence := math.Inf(1), math.Inf(-1)
+	for _, r := range individual {
+		minConfidence = math.Min(minConfidence, r.Decision.Probability)
+		maxConfidence = math.Max(maxConfidence, r.Decision.Probability)
+	}
+	confidenceDiff := maxConfidence - minConfidence
 	fmt.Printf("\nDiferença de confiança: %.2f%%\n", confidenceDiff)
 
 	if confidenceDiff < 10 {
@@ -407,184 +556,1828 @@ func compareAlgorithms(url string, records []models.NewsRecord) {
 	} else {
 		fmt.Println("📊 Alta divergência entre os algoritmos")
 	}
+}
 
-	// Comparação de performance geral
-	fmt.Println("\n=== COMPARAÇÃO DE PERFORMANCE GERAL ===")
-	fmt.Printf("MLP vs Naive Bayes:\n")
-	fmt.Printf("  Acurácia:   %.4f vs %.4f (diferença: %.4f)\n",
-		mlpMetrics.Accuracy, nbMetrics.Accuracy, mlpMetrics.Accuracy-nbMetrics.Accuracy)
-	fmt.Printf("  Precisão:   %.4f vs %.4f (diferença: %.4f)\n",
-		mlpMetrics.Precision, nbMetrics.Precision, mlpMetrics.Precision-nbMetrics.Precision)
-	fmt.Printf("  Revocação:  %.4f vs %.4f (diferença: %.4f)\n",
-		mlpMetrics.Recall, nbMetrics.Recall, mlpMetrics.Recall-nbMetrics.Recall)
-	fmt.Printf("  F1-Score:   %.4f vs %.4f (diferença: %.4f)\n",
-		mlpMetrics.F1Score, nbMetrics.F1Score, mlpMetrics.F1Score-nbMetrics.F1Score)
+// compareAlgorithms compara os algoritmos em uma notícia (URL, arquivo ou stdin),
+// incluindo as métricas de cross-validation de cada um e o ensemble
+func compareAlgorithms(source string, docs []models.Document) {
+	content := loadArticle(source, docs)
+	if content == nil {
+		return
+	}
 
-	fmt.Println(strings.Repeat("=", 120))
-}
+	algorithms := append(append([]string{}, comparedAlgorithms...), "Ensemble")
 
-// compareAlgorithmsFast compara MLP e Naive Bayes em uma URL específica (versão rápida sem cross-validation)
-func compareAlgorithmsFast(url string, records []models.NewsRecord) {
-	fmt.Printf("Analisando a URL: %s\n", url)
+	// Calcular métricas de cross-validation primeiro
+	fmt.Println("=== CALCULANDO MÉTRICAS DE PERFORMANCE ===")
+	fmt.Println("Executando 5-fold cross-validation...")
+	fmt.Println("(Isso pode levar alguns minutos devido ao treinamento do MLP)")
 
-	articleText, err := crawler.CrawlNews(url)
-	if err != nil {
-		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	metrics := make(map[string]models.Metrics)
+	for _, algorithm := range algorithms {
+		metrics[algorithm] = evaluateModel(docs, algorithm)
 	}
 
-	if strings.TrimSpace(articleText) == "" {
-		fmt.Println("Não foi possível extrair texto relevante da página.")
-		return
+	results := analyzeWithAlgorithms(algorithms, docs, content.Text, articleLink(content))
+	for i := range results {
+		results[i].Metrics = metrics[results[i].Name]
 	}
 
-	if len(articleText) < 300 {
-		fmt.Printf("[AVISO] O texto extraído é muito pequeno (%d caracteres). O resultado pode não ser confiável.\n", len(articleText))
-	}
-	fmt.Printf("Texto extraído (%d caracteres): %s...\n\n", len(articleText), articleText[:utils.Min(200, len(articleText))])
+	// Imprimir comparação
+	fmt.Println("\n" + strings.Repeat("=", 120))
+	fmt.Println("COMPARAÇÃO ENTRE ALGORITMOS")
+	fmt.Println(strings.Repeat("=", 120))
+	fmt.Printf("Fonte analisada: %s (%s)\n", content.Origin, content.Format)
+	printSourceReputation(sourceModel(docs).Prior(articleLink(content)))
+	printNearCopies(docs, content.Text)
+	printSimilarArticles(docs, content.Text)
+	fmt.Println()
 
-	// Verificar heurística
-	lowerText := strings.ToLower(articleText)
-	if strings.Contains(lowerText, "boato") || strings.Contains(lowerText, "falso") ||
-		strings.Contains(lowerText, "mentira") || strings.Contains(lowerText, "desmentido") ||
-		strings.Contains(lowerText, "fake news") {
-		fmt.Println("[HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
-		fmt.Println("--- Resultado da Análise ---")
-		fmt.Println("Classificação: Provavelmente Falsa (por heurística)")
-		fmt.Println("----------------------------")
-		return
-	}
+	// Tabela de resultados com métricas de cross-validation
+	printComparisonTable(results, true)
+	fmt.Println(strings.Repeat("=", 120))
 
-	// Treinar e testar MLP
-	fmt.Println("=== ANÁLISE COM MLP ===")
-	mlpClassifier := mlp.NewClassifier(1000, 50, 2)
-	mlpClassifier.Train(records)
-	mlpLabel, mlpConfidence, mlpProbs, mlpTokens := mlpClassifier.ClassifyWithDebug(articleText)
+	// Detalhes dos tokens influentes
+	fmt.Println("\n=== TOKENS MAIS INFLUENTES (positivo = favorece a classe) ===")
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s:\n", algorithmLabel(r.Name))
+		printContributions(r.Prediction.Contributions, 5)
+	}
 
-	// Treinar e testar Naive Bayes
-	fmt.Println("\n=== ANÁLISE COM NAIVE BAYES ===")
-	nbClassifier := naivebayes.NewClassifier()
-	nbClassifier.TrainNB(records)
-	nbLabel, nbConfidence, nbProbs, nbTokens := nbClassifier.ClassifyWithDebugNB(articleText)
+	printAgreement(results)
 
-	// Mapear labels para exibição
-	var mlpResult, nbResult string
-	if mlpLabel == "true" {
-		mlpResult = "Provavelmente Verdadeira"
-	} else {
-		mlpResult = "Provavelmente Falsa"
+	// Comparação de performance geral
+	fmt.Println("\n=== COMPARAÇÃO DE PERFORMANCE GERAL ===")
+	fmt.Printf("%-22s %-10s %-10s %-10s %-10s %-10s\n", "Algoritmo", "Acurácia", "Precisão", "Revocação", "F1-Score", "ECE")
+	best := results[0]
+	for _, r := range results {
+		fmt.Printf("%-22s %-10.4f %-10.4f %-10.4f %-10.4f %-10.4f\n", algorithmLabel(r.Name),
+			r.Metrics.Accuracy, r.Metrics.Precision, r.Metrics.Recall, r.Metrics.F1Score, r.Metrics.ECE)
+		if r.Metrics.F1Score > best.Metrics.F1Score {
+			best = r
+		}
 	}
+	fmt.Printf("Melhor F1-Score: %s (%.4f) | ECE menor = probabilidades mais calibradas\n",
+		algorithmLabel(best.Name), best.Metrics.F1Score)
 
-	if nbLabel == "true" {
-		nbResult = "Provavelmente Verdadeira"
-	} else {
-		nbResult = "Provavelmente Falsa"
+	fmt.Println(strings.Repeat("=", 120))
+}
+
+// compareAlgorithmsFast compara os algoritmos em uma notícia (versão rápida sem cross-validation)
+func compareAlgorithmsFast(source string, docs []models.Document) {
+	content := loadArticle(source, docs)
+	if content == nil {
+		return
 	}
 
+	results := analyzeWithAlgorithms(comparedAlgorithms, docs, content.Text, articleLink(content))
+
 	// Imprimir comparação
 	fmt.Println("\n" + strings.Repeat("=", 80))
 	fmt.Println("COMPARAÇÃO ENTRE ALGORITMOS (VERSÃO RÁPIDA)")
 	fmt.Println(strings.Repeat("=", 80))
-	fmt.Printf("URL analisada: %s\n\n", url)
+	fmt.Printf("Fonte analisada: %s (%s)\n", content.Origin, content.Format)
+	printSourceReputation(sourceModel(docs).Prior(articleLink(content)))
+	printNearCopies(docs, content.Text)
+	printSimilarArticles(docs, content.Text)
+	fmt.Println()
 
 	// Tabela de resultados (sem métricas de cross-validation)
-	fmt.Printf("%-20s %-25s %-15s %-20s\n", "Algoritmo", "Classificação", "Confiança", "Probabilidades")
-	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("%-20s %-25s %-15.2f%% %-20s\n", "MLP", mlpResult, mlpConfidence,
-		fmt.Sprintf("V:%.1f%% F:%.1f%%", mlpProbs["true"], mlpProbs["fake"]))
-	fmt.Printf("%-20s %-25s %-15.2f%% %-20s\n", "Naive Bayes", nbResult, nbConfidence,
-		fmt.Sprintf("V:%.1f%% F:%.1f%%", nbProbs["true"], nbProbs["fake"]))
+	printComparisonTable(results, false)
 	fmt.Println(strings.Repeat("=", 80))
 
 	// Detalhes dos tokens influentes
-	fmt.Println("\n=== TOKENS MAIS INFLUENTES ===")
-	fmt.Println("MLP:")
-	for i, token := range mlpTokens {
-		if i < 5 {
-			fmt.Printf("  %s\n", token)
+	fmt.Println("\n=== TOKENS MAIS INFLUENTES (positivo = favorece a classe) ===")
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
 		}
+		fmt.Printf("%s:\n", algorithmLabel(r.Name))
+		printContributions(r.Prediction.Contributions, 5)
 	}
-	fmt.Println("\nNaive Bayes:")
-	for i, token := range nbTokens {
-		if i < 5 {
-			fmt.Printf("  %s\n", token)
-		}
+
+	printAgreement(results)
+
+	fmt.Println(strings.Repeat("=", 80))
+}
+
+// importCache importa páginas HTML salvas para o cache do crawler
+func importCache(args []string) {
+	fs := flag.NewFlagSet("cache-import", flag.ExitOnError)
+	url := fs.String("url", "", "URL associada ao arquivo (padrão: detectar pelo HTML)")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fmt.Println("Erro: arquivo ou diretório HTML necessário")
+		fmt.Println("Uso: go run cmd/classifier/main.go cache-import [--url <url>] <arquivo.html|diretório>...")
+		return
 	}
 
-	// Análise de concordância
-	fmt.Println("\n=== ANÁLISE DE CONCORDÂNCIA ===")
-	if mlpLabel == nbLabel {
-		fmt.Printf("✅ Os algoritmos concordam: %s\n", mlpResult)
-	} else {
-		fmt.Printf("❌ Os algoritmos discordam:\n")
-		fmt.Printf("   MLP: %s (%.2f%%)\n", mlpResult, mlpConfidence)
-		fmt.Printf("   NB:  %s (%.2f%%)\n", nbResult, nbConfidence)
+	var paths []string
+	for _, arg := range fs.Args() {
+		info, err := os.Stat(arg)
+		if err != nil {
+			log.Fatalf("Erro ao acessar %s: %v", arg, err)
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		err = filepath.WalkDir(arg, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if !d.IsDir() && (ext == ".html" || ext == ".htm") {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("Erro ao percorrer %s: %v", arg, err)
+		}
 	}
 
-	// Diferença de confiança
-	confidenceDiff := math.Abs(mlpConfidence - nbConfidence)
-	fmt.Printf("\nDiferença de confiança: %.2f%%\n", confidenceDiff)
+	if *url != "" && len(paths) > 1 {
+		log.Fatalf("Erro: --url só pode ser usada com um único arquivo")
+	}
 
-	if confidenceDiff < 10 {
-		fmt.Println("📊 Baixa divergência entre os algoritmos")
-	} else if confidenceDiff < 25 {
-		fmt.Println("📊 Divergência moderada entre os algoritmos")
-	} else {
-		fmt.Println("📊 Alta divergência entre os algoritmos")
+	imported := 0
+	for _, path := range paths {
+		pageURL, err := crawler.ImportHTML(path, *url)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			continue
+		}
+		fmt.Printf("✅ %s -> %s\n", path, pageURL)
+		imported++
 	}
 
-	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("\n%d/%d arquivos importados para o cache\n", imported, len(paths))
 }
 
-// main é o ponto de entrada da aplicação
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Uso:")
-		fmt.Println("  go run cmd/classifier/main.go <url>                     # Analisa URL com MLP e NB")
-		fmt.Println("  go run cmd/classifier/main.go mlp <url>                 # Usa apenas MLP")
-		fmt.Println("  go run cmd/classifier/main.go nb <url>                  # Usa apenas Naive Bayes")
-		fmt.Println("  go run cmd/classifier/main.go fast <url>                # Comparação rápida (sem cross-validation)")
-		fmt.Println("")
-		fmt.Println("Exemplos:")
-		fmt.Println("  go run cmd/classifier/main.go https://g1.globo.com/...")
-		fmt.Println("  go run cmd/classifier/main.go mlp https://g1.globo.com/...")
-		fmt.Println("  go run cmd/classifier/main.go nb https://g1.globo.com/...")
-		fmt.Println("  go run cmd/classifier/main.go fast https://g1.globo.com/...")
-		return
+// decisionPolicy transforma probabilidades em vereditos, definida pelas flags
+// --min-confidence, --threshold, --cost e --abstain-cost
+var decisionPolicy = decision.DefaultPolicy()
+
+// configurePolicy monta a política de decisão a partir das flags globais
+func configurePolicy(minConfidence float64, thresholds, costs []string, abstainCost float64) {
+	parsedThresholds, err := decision.ParseAssignments(thresholds)
+	if err != nil {
+		log.Fatalf("Erro em --threshold: %v", err)
 	}
+	parsedCosts, err := decision.ParseAssignments(costs)
+	if err != nil {
+		log.Fatalf("Erro em --cost: %v", err)
+	}
+	// Com abstenção gratuita, o limiar de menor custo seria sempre o de cobertura quase nula
+	if len(parsedCosts) > 0 && abstainCost <= 0 {
+		log.Fatalf("Erro: --cost exige --abstain-cost maior que zero (abster-se não pode ser gratuito)")
+	}
+
+	decisionPolicy = decision.Policy{
+		MinProbability: minConfidence,
+		Thresholds:     parsedThresholds,
+		Costs:          parsedCosts,
+		AbstainCost:    abstainCost,
+	}
+}
+
+// calibrationMethod é o método de calibração das probabilidades, definido pela flag --calibration
+var calibrationMethod = calibration.MethodNone
+
+// newClassifier cria o classificador correspondente ao algoritmo (ver baseFactory),
+// envolvido pelo calibrador escolhido em --calibration
+func newClassifier(algorithm string) models.Classifier {
+	factory := baseFactory(algorithm, 0)
 
-	// Carregar dataset
-	datasetURL := "https://raw.githubusercontent.com/jpchav98/FakeTrue.Br/refs/heads/main/FakeTrueBr_corpus.csv"
-	records, err := loadDatasetFromURL(datasetURL)
+	calibrator, err := calibration.New(calibrationMethod)
 	if err != nil {
-		log.Fatalf("Falha ao carregar o dataset: %v", err)
+		log.Fatalf("Erro: %v", err)
+	}
+	if calibrator == nil {
+		return factory()
 	}
+	return calibration.NewClassifier(factory, calibrator)
+}
 
-	if os.Args[1] == "mlp" {
-		if len(os.Args) < 3 {
-			fmt.Println("Erro: URL necessária para classificação com MLP")
-			fmt.Println("Uso: go run cmd/classifier/main.go mlp <url>")
-			return
+// algorithmName converte o nome curto usado na linha de comando no nome do algoritmo
+func algorithmName(short string) string {
+	switch strings.ToLower(short) {
+	case "mlp":
+		return "MLP"
+	case "ensemble":
+		return "Ensemble"
+	case "logreg", "lr":
+		return "Regressão Logística"
+	case "svm":
+		return "SVM Linear"
+	case "knn":
+		return "kNN"
+	case "centroid", "rocchio":
+		return "Centroide"
+	}
+	return "Naive Bayes"
+}
+
+// printContributions imprime os n tokens mais influentes com a contribuição assinada
+// para cada classe (positivo = favorece a classe)
+func printContributions(contributions []models.TokenContribution, n int) {
+	for i, contrib := range contributions {
+		if i >= n {
+			break
 		}
-		classifyNews(os.Args[2], "MLP")
+		var scores []string
+		for _, class := range orderedClasses(contrib.Scores) {
+			scores = append(scores, fmt.Sprintf("%s:%+8.4f", shortLabel(class), contrib.Scores[class]))
+		}
+		fmt.Printf("  %-20s %s  (x%d)\n", contrib.Token, strings.Join(scores, "  "), contrib.Count)
+	}
+}
 
-	} else if os.Args[1] == "nb" {
-		if len(os.Args) < 3 {
-			fmt.Println("Erro: URL necessária para classificação com Naive Bayes")
-			fmt.Println("Uso: go run cmd/classifier/main.go nb <url>")
-			return
+// displayLabel mapeia o rótulo para exibição (rótulos além de true/fake são exibidos como estão)
+func displayLabel(label string) string {
+	switch label {
+	case decision.Inconclusive:
+		return "Inconclusiva"
+	case "true":
+		return "Provavelmente Verdadeira"
+	case "fake":
+		return "Provavelmente Falsa"
+	}
+	return label
+}
+
+// classLabel retorna o nome da classe para listas de probabilidades
+func classLabel(label string) string {
+	switch label {
+	case "true":
+		return "Verdadeira"
+	case "fake":
+		return "Falsa"
+	}
+	return label
+}
+
+// shortLabel retorna a abreviação da classe usada nas tabelas (V, F ou o próprio rótulo)
+func shortLabel(label string) string {
+	switch label {
+	case "true":
+		return "V"
+	case "fake":
+		return "F"
+	}
+	return label
+}
+
+// orderedClasses retorna as classes na ordem de exibição: "true", "fake" e as
+// demais em ordem alfabética
+func orderedClasses[V any](byClass map[string]V) []string {
+	var classes []string
+	for _, class := range []string{"true", "fake"} {
+		if _, ok := byClass[class]; ok {
+			classes = append(classes, class)
+		}
+	}
+	var others []string
+	for class := range byClass {
+		if class != "true" && class != "fake" {
+			others = append(others, class)
 		}
-		classifyNews(os.Args[2], "Naive Bayes")
+	}
+	sort.Strings(others)
+	return append(classes, others...)
+}
+
+// formatProbabilities formata as probabilidades de cada classe ("Verdadeira: 80.00% | Falsa: 20.00%")
+func formatProbabilities(probs map[string]float64) string {
+	var parts []string
+	for _, class := range orderedClasses(probs) {
+		parts = append(parts, fmt.Sprintf("%s: %.2f%%", classLabel(class), probs[class]))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// compactProbabilities formata as probabilidades para tabelas ("V:80.0% F:20.0%")
+func compactProbabilities(probs map[string]float64) string {
+	var parts []string
+	for _, class := range orderedClasses(probs) {
+		parts = append(parts, fmt.Sprintf("%s:%.1f%%", shortLabel(class), probs[class]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// printDecision imprime o veredito de um algoritmo e, se inconclusivo, o motivo
+func printDecision(name string, d decision.Decision) {
+	if d.Inconclusive() {
+		fmt.Printf("   %-21s %s - %s\n", name+":", displayLabel(d.Label), d.Reason)
+		return
+	}
+	fmt.Printf("   %-21s %s (%.2f%%)\n", name+":", displayLabel(d.Label), d.Probability)
+}
+
+// runSegment classifica cada parágrafo/sentença da notícia e destaca os trechos suspeitos
+func runSegment(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("segment", flag.ExitOnError)
+	algo := fs.String("algorithm", "nb", "algoritmo: nb, mlp, logreg, svm, knn, centroid ou ensemble")
+	level := fs.String("level", segment.LevelSentence, "segmentação: sentence ou paragraph")
+	minTokens := fs.Int("min-tokens", 3, "mínimo de tokens para pontuar um trecho")
+	top := fs.Int("top", 5, "número de trechos suspeitos exibidos")
+	htmlOut := fs.String("html", "", "arquivo HTML do relatório com trechos destacados")
+	target := fs.String("target", "fake", "classe cujos trechos responsáveis são destacados")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para a análise segmentada")
+		fmt.Println("Uso: go run cmd/classifier/main.go segment [--algorithm nb|mlp|logreg|svm|ensemble] [--level sentence|paragraph] [--target fake] [--html relatorio.html] <fonte>")
+		return
+	}
+	if *level != segment.LevelSentence && *level != segment.LevelParagraph {
+		log.Fatalf("Erro: segmentação inválida %q (use sentence ou paragraph)", *level)
+	}
+	checkClass("target", *target, docs)
+
+	source := fs.Arg(0)
+	fmt.Printf("Analisando: %s\n", source)
+	content, err := input.Load(source, inputFormat)
+	if err != nil {
+		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	}
+	if strings.TrimSpace(content.Text) == "" {
+		fmt.Println("Não foi possível extrair texto relevante.")
+		return
+	}
+
+	algorithm := algorithmName(*algo)
+	fmt.Printf("Treinando classificador %s...\n", algorithm)
+	classifier := newClassifier(algorithm)
+	classifier.Train(docs)
+
+	opts := segment.DefaultOptions()
+	opts.Level = *level
+	opts.MinTokens = *minTokens
+	opts.Target = *target
+	analysis := segment.Analyze(classifier, content.Text, opts)
+
+	scored := 0
+	for _, s := range analysis.Segments {
+		if s.Scored {
+			scored++
+		}
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("ANÁLISE SEGMENTADA")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Fonte analisada: %s (%s)\n", content.Origin, content.Format)
+	fmt.Printf("Algoritmo: %s | Segmentação: %s | Trechos pontuados: %d/%d\n",
+		algorithm, *level, scored, len(analysis.Segments))
+	fmt.Printf("Veredito agregado: %s (%.2f%%) | %s\n",
+		displayLabel(decisionPolicy.Decide(analysis.Probabilities).Label), analysis.Confidence,
+		compactProbabilities(analysis.Probabilities))
+	fmt.Printf("Texto inteiro:     %s (%.2f%%)\n",
+		displayLabel(decisionPolicy.Decide(analysis.Document.Probabilities).Label), analysis.Document.Confidence)
+
+	fmt.Printf("\n=== TRECHOS QUE MAIS CONTRIBUÍRAM PARA %q ===\n", strings.ToUpper(classLabel(*target)))
+	drivers := analysis.Drivers(*top)
+	if len(drivers) == 0 {
+		fmt.Printf("Nenhum trecho favorece a classe %s.\n", *target)
+	}
+	for _, d := range drivers {
+		text := d.Text
+		if len([]rune(text)) > 150 {
+			text = string([]rune(text)[:147]) + "..."
+		}
+		fmt.Printf("#%-4d %s:%5.1f%%  influência %+.2f\n      %s\n", d.Index, shortLabel(*target), d.Result.Probabilities[*target], d.Influence, text)
+	}
+	fmt.Println(strings.Repeat("=", 80))
+
+	if *htmlOut != "" {
+		file, err := os.Create(*htmlOut)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *htmlOut, err)
+		}
+		defer file.Close()
+		if err := analysis.WriteHTML(file, content.Origin); err != nil {
+			log.Fatalf("Erro ao gerar relatório: %v", err)
+		}
+		fmt.Printf("Relatório HTML gravado em %s\n", *htmlOut)
+	}
+}
+
+// runEvaluate avalia um algoritmo com cross-validation e compara a calibração
+// das probabilidades brutas com a de cada método de calibração
+func runEvaluate(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("evaluate", flag.ExitOnError)
+	algo := fs.String("algorithm", "nb", "algoritmo: nb, mlp, logreg, svm, knn, centroid ou ensemble")
+	folds := fs.Int("folds", 5, "número de folds da cross-validation")
+	bins := fs.Int("bins", 10, "número de faixas do diagrama de confiabilidade")
+	targetAccuracy := fs.Float64("target-accuracy", 0.9, "acurácia desejada para sugerir um limiar de decisão (0-1)")
+	leakage := fs.Bool("leakage", true, "detecta quase-duplicados entre treino e teste e compara as métricas sem os documentos vazados")
+	fs.Parse(args)
+
+	if *folds < 2 {
+		log.Fatalf("Erro: são necessários pelo menos 2 folds")
+	}
+
+	algorithm := algorithmName(*algo)
+	fmt.Printf("Avaliando modelo %s com %d-fold cross-validation...\n", algorithm, *folds)
+	predictions := evaluation.CrossValidate(docs, cvFactory(algorithm), *folds, func(fold, total int) {
+		fmt.Printf("Fold %d/%d\n", fold, total)
+	})
+	if augmentOptions.Copies > 0 {
+		printAugmentationEffect(docs, algorithm, predictions, *folds, *bins)
+	}
+	if sourcePrior {
+		predictions = applySourcePrior(docs, predictions, *folds, *bins)
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Printf("AVALIAÇÃO DE CALIBRAÇÃO - %s (%d documentos)\n", algorithm, len(predictions))
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("%-14s %-10s %-10s %-10s %-10s %-10s\n", "Calibração", "Acurácia", "Precisão", "Revocação", "F1-Score", "ECE")
+	fmt.Println(strings.Repeat("-", 80))
+
+	calibrated := make(map[string][]evaluation.Prediction)
+	for _, method := range calibration.Methods {
+		preds, err := calibration.CrossCalibrate(method, predictions)
+		if err != nil {
+			log.Fatalf("Erro na calibração: %v", err)
+		}
+		calibrated[method] = preds
+
+		metrics := evaluation.Evaluate(preds, *bins)
+		fmt.Printf("%-14s %-10.4f %-10.4f %-10.4f %-10.4f %-10.4f\n", method,
+			metrics.Accuracy, metrics.Precision, metrics.Recall, metrics.F1Score, metrics.ECE)
+	}
+
+	printClassReport(evaluation.Evaluate(calibrated[calibrationMethod], *bins))
+
+	if *leakage {
+		report, err := evaluation.DetectLeakage(docs, *folds, duplicateOptions)
+		if err != nil {
+			log.Fatalf("Erro na detecção de quase-duplicados: %v", err)
+		}
+		printLeakageReport(report, calibrated[calibrationMethod], *bins)
+	}
+
+	// Diagrama de confiabilidade: probabilidades brutas e método escolhido em --calibration
+	methods := []string{calibration.MethodNone}
+	if calibrationMethod != calibration.MethodNone {
+		methods = append(methods, calibrationMethod)
+	}
+	for _, method := range methods {
+		reliability, ece := evaluation.Reliability(calibrated[method], *bins)
+		fmt.Printf("\nDiagrama de confiabilidade (%s, ECE %.4f):\n", method, ece)
+		fmt.Printf("%-14s %-8s %-12s %-10s %s\n", "Confiança", "Docs", "Conf. média", "Acurácia", "")
+		for _, bin := range reliability {
+			if bin.Count == 0 {
+				continue
+			}
+			fmt.Printf("%3.0f%% - %3.0f%%   %-8d %-12.4f %-10.4f %s\n",
+				bin.Lower*100, bin.Upper*100, bin.Count, bin.Confidence, bin.Accuracy,
+				strings.Repeat("█", int(bin.Accuracy*20+0.5)))
+		}
+	}
+
+	printCoverageCurve(calibrated[calibrationMethod], *targetAccuracy)
+
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println("Calibração ajustada fold a fold: cada fold é calibrado com as predições dos demais.")
+}
+
+// printClassReport imprime as métricas de cada classe e a matriz de confusão
+func printClassReport(metrics models.Metrics) {
+	classes := orderedClasses(metrics.PerClass)
+
+	fmt.Printf("\nMétricas por classe (%s):\n", calibrationMethod)
+	fmt.Printf("%-14s %-10s %-10s %-10s %-8s\n", "Classe", "Precisão", "Revocação", "F1-Score", "Docs")
+	for _, class := range classes {
+		m := metrics.PerClass[class]
+		fmt.Printf("%-14s %-10.4f %-10.4f %-10.4f %-8d\n", class, m.Precision, m.Recall, m.F1Score, m.Support)
+	}
+	for _, class := range classes {
+		if class != "true" && class != "fake" {
+			fmt.Println("Precisão, revocação e F1 da tabela geral: média macro entre as classes.")
+			break
+		}
+	}
+
+	fmt.Println("\nMatriz de confusão (linhas = classe real, colunas = prevista):")
+	fmt.Printf("%-14s", "")
+	for _, class := range classes {
+		fmt.Printf(" %10s", truncate(class, 10))
+	}
+	fmt.Println()
+	for _, actual := range classes {
+		fmt.Printf("%-14s", truncate(actual, 14))
+		for _, predicted := range classes {
+			fmt.Printf(" %10d", metrics.Confusion[actual][predicted])
+		}
+		fmt.Println()
+	}
+}
+
+// printAugmentationEffect repete a cross-validation com os mesmos folds sem o aumento
+// de dados e compara as métricas com as do treinamento aumentado
+func printAugmentationEffect(docs []models.Document, algorithm string, augmented []evaluation.Prediction, folds, bins int) {
+	fmt.Println("\nRepetindo a cross-validation sem aumento de dados para comparação...")
+	copies := augmentOptions.Copies
+	augmentOptions.Copies = 0
+	baseline := evaluation.CrossValidate(docs, cvFactory(algorithm), folds, nil)
+	augmentOptions.Copies = copies
+
+	fmt.Printf("\nAumento de dados (%d cópias por documento, intensidade %g, operações %s; só nos folds de treinamento):\n",
+		augmentOptions.Copies, augmentOptions.Strength, strings.Join(augmentOptions.Operations, ", "))
+	fmt.Printf("%-14s %-10s %-10s %-10s %-10s %-10s\n", "Treinamento", "Acurácia", "Precisão", "Revocação", "F1-Score", "ECE")
+	for _, row := range []struct {
+		name  string
+		preds []evaluation.Prediction
+	}{{"sem aumento", baseline}, {"com aumento", augmented}} {
+		metrics := evaluation.Evaluate(row.preds, bins)
+		fmt.Printf("%-14s %-10.4f %-10.4f %-10.4f %-10.4f %-10.4f\n", row.name,
+			metrics.Accuracy, metrics.Precision, metrics.Recall, metrics.F1Score, metrics.ECE)
+	}
+}
+
+// applySourcePrior combina as predições out-of-fold com o prior de fonte de cada fold
+// e compara as métricas do modelo de texto, da fonte sozinha e da combinação
+func applySourcePrior(docs []models.Document, predictions []evaluation.Prediction, folds, bins int) []evaluation.Prediction {
+	sources, priors := reputation.CrossPriors(docs, predictions, folds, reputationOptions, reputationList)
+	combined := make([]evaluation.Prediction, len(predictions))
+	sourceOnly := make([]evaluation.Prediction, len(predictions))
+	known := 0
+	for i, pred := range predictions {
+		combined[i], sourceOnly[i] = pred, pred
+		combined[i].Result = sources[i].Combine(pred.Result, priors[i])
+		sourceOnly[i].Result = sources[i].Predict(priors[i])
+		if priors[i].Known() {
+			known++
+		}
+	}
+
+	fmt.Printf("\nPrior de fonte (peso %g): %d de %d documentos com domínio conhecido no treinamento do fold\n",
+		reputationOptions.Weight, known, len(predictions))
+	fmt.Printf("%-14s %-10s %-10s %-10s %-10s %-10s\n", "Entrada", "Acurácia", "Precisão", "Revocação", "F1-Score", "ECE")
+	for _, row := range []struct {
+		name  string
+		preds []evaluation.Prediction
+	}{{"texto", predictions}, {"fonte", sourceOnly}, {"texto+fonte", combined}} {
+		metrics := evaluation.Evaluate(row.preds, bins)
+		fmt.Printf("%-14s %-10.4f %-10.4f %-10.4f %-10.4f %-10.4f\n", row.name,
+			metrics.Accuracy, metrics.Precision, metrics.Recall, metrics.F1Score, metrics.ECE)
+	}
+	return combined
+}
+
+// printLeakageReport imprime os quase-duplicados entre treino e teste de cada fold
+// e compara as métricas de todos os documentos com as dos documentos sem vazamento
+func printLeakageReport(report evaluation.LeakageReport, predictions []evaluation.Prediction, bins int) {
+	fmt.Printf("\nVazamento entre treino e teste (quase-duplicados com Jaccard ≥ %.2f):\n", report.Threshold)
+	fmt.Printf("Pares de quase-duplicados: %d (%d no mesmo grupo, %d no mesmo fold, %d separados entre treino e teste)\n",
+		report.Pairs, report.SameGroup, report.SameFold, report.CrossFold)
+	fmt.Printf("%-8s %-8s %-10s %-12s %-12s %-10s\n", "Fold", "Teste", "Vazados", "Mesmo rót.", "Outro rót.", "Sim. média")
+	for _, fold := range append(report.Folds, report.Total) {
+		name := "total"
+		if fold.Fold >= 0 {
+			name = strconv.Itoa(fold.Fold + 1)
+		}
+		fmt.Printf("%-8s %-8d %-10d %-12d %-12d %-10.4f\n", name, fold.Test, fold.Leaked, fold.SameLabel, fold.CrossLabel, fold.MeanSimilarity)
+	}
+
+	if report.Total.Leaked == 0 {
+		fmt.Println("Nenhum documento de teste tem quase-duplicado no treinamento.")
+		return
+	}
+
+	clean := report.WithoutLeaked(predictions)
+	fmt.Printf("\nMétricas com e sem os documentos vazados (%s):\n", calibrationMethod)
+	fmt.Printf("%-16s %-8s %-10s %-10s %-10s %-10s %-10s\n", "Documentos", "Docs", "Acurácia", "Precisão", "Revocação", "F1-Score", "ECE")
+	rows := []struct {
+		name  string
+		preds []evaluation.Prediction
+	}{{"todos", predictions}, {"sem vazamento", clean}}
+	for _, row := range rows {
+		metrics := evaluation.Evaluate(row.preds, bins)
+		fmt.Printf("%-16s %-8d %-10.4f %-10.4f %-10.4f %-10.4f %-10.4f\n", row.name, len(row.preds),
+			metrics.Accuracy, metrics.Precision, metrics.Recall, metrics.F1Score, metrics.ECE)
+	}
+	if !groupDuplicates {
+		fmt.Println("Use --group-duplicates para manter quase-duplicados no mesmo fold.")
+	}
+}
+
+// truncate limita o texto a n caracteres
+func truncate(text string, n int) string {
+	if runes := []rune(text); len(runes) > n {
+		return string(runes[:n])
+	}
+	return text
+}
+
+// printCoverageCurve imprime a curva cobertura × acurácia dos limiares de decisão
+// e o resultado da política configurada pelas flags globais
+func printCoverageCurve(predictions []evaluation.Prediction, targetAccuracy float64) {
+	var thresholds []float64
+	for t := 50.0; t < 100; t += 5 {
+		thresholds = append(thresholds, t)
+	}
+	curve := decision.Curve(decisionPolicy, predictions, thresholds)
+	withCosts := len(decisionPolicy.Costs) > 0
+
+	fmt.Printf("\nCobertura × acurácia por limiar de decisão (%s):\n", calibrationMethod)
+	fmt.Printf("%-10s %-10s %-10s %-10s", "Limiar", "Cobertura", "Acurácia", "Vereditos")
+	if withCosts {
+		fmt.Printf(" %-10s", "Custo/doc")
+	}
+	fmt.Println()
+	for _, point := range curve {
+		fmt.Printf("%-10s %-10.4f %-10.4f %-10d", fmt.Sprintf("%.0f%%", point.Threshold), point.Coverage, point.Accuracy, point.Decided)
+		if withCosts {
+			fmt.Printf(" %-10.4f", point.Cost)
+		}
+		fmt.Println()
+	}
+
+	if point, ok := decision.ThresholdForAccuracy(curve, targetAccuracy); ok {
+		fmt.Printf("Limiar sugerido para acurácia ≥ %.2f: --min-confidence %.0f (cobertura %.1f%%)\n",
+			targetAccuracy, point.Threshold, point.Coverage*100)
+	} else {
+		fmt.Printf("Nenhum limiar atinge acurácia ≥ %.2f\n", targetAccuracy)
+	}
+	if withCosts {
+		point := decision.CheapestThreshold(curve)
+		fmt.Printf("Limiar de menor custo: --min-confidence %.0f (custo médio %.4f por documento)\n", point.Threshold, point.Cost)
+	}
+
+	outcome := decision.Evaluate(decisionPolicy, predictions)
+	fmt.Printf("Política atual: cobertura %.1f%% | acurácia %.4f | %d inconclusivos",
+		outcome.Coverage*100, outcome.Accuracy, outcome.Total-outcome.Decided)
+	if withCosts || decisionPolicy.AbstainCost > 0 {
+		fmt.Printf(" | custo médio %.4f", outcome.Cost)
+	}
+	fmt.Println()
+}
+
+// runTune busca os hiperparâmetros de um algoritmo com cross-validation aninhada,
+// imprime o leaderboard e grava a melhor configuração em um arquivo reutilizável
+func runTune(args []string, docs []models.Document) {
+	opts := tuning.DefaultOptions()
+	fs := flag.NewFlagSet("tune", flag.ExitOnError)
+	algo := fs.String("algorithm", "nb", "algoritmo: nb, mlp, logreg, svm, knn ou centroid")
+	fs.StringVar(&opts.Search, "search", opts.Search, "estratégia de busca: grid ou random")
+	fs.IntVar(&opts.Trials, "trials", opts.Trials, "configurações sorteadas na busca aleatória")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "semente da busca aleatória")
+	fs.IntVar(&opts.OuterFolds, "outer-folds", opts.OuterFolds, "folds externos (estimativa do desempenho)")
+	fs.IntVar(&opts.InnerFolds, "inner-folds", opts.InnerFolds, "folds internos (seleção da configuração)")
+	fs.IntVar(&opts.Workers, "workers", 0, "treinamentos em paralelo (0 = número de CPUs)")
+	fs.StringVar(&opts.Metric, "metric", opts.Metric, "métrica otimizada: "+strings.Join(tuning.Metrics, ", "))
+	var overrides stringList
+	fs.Var(&overrides, "param", "redefine o espaço de um parâmetro: nome=v1,v2 (grade) ou nome=min:max (aleatória); pode repetir")
+	out := fs.String("out", "config.json", "arquivo de configuração com a melhor configuração (atualizado se existir)")
+	leaderboardOut := fs.String("leaderboard", "leaderboard.csv", "arquivo CSV com todas as configurações avaliadas")
+	top := fs.Int("top", 10, "configurações exibidas no leaderboard")
+	fs.Parse(args)
+
+	algorithm, ok := tuning.Lookup(algorithmName(*algo))
+	if !ok {
+		log.Fatalf("Erro: o algoritmo %s não tem hiperparâmetros ajustáveis", algorithmName(*algo))
+	}
+	// Parâmetros definidos pelas flags globais ficam fixos no espaço (a menos que
+	// redefinidos por --param); os de --config e os fora do espaço entram na base
+	searched := make(map[string]bool)
+	for _, assignment := range overrides {
+		name, _, _ := strings.Cut(assignment, "=")
+		searched[strings.TrimSpace(name)] = true
+	}
+	var fixed []string
+	for _, name := range hyperparameterFlags.Names() {
+		if algorithm.Space.Has(name) && !searched[name] {
+			fixed = append(fixed, name+"="+hyperparameterFlags[name])
+		}
+	}
+	space, err := algorithm.Space.Override(append(fixed, overrides...))
+	if err != nil {
+		log.Fatalf("Erro em --param: %v", err)
+	}
+	opts.Base = hyperparameters.Params(algorithm.Name)
+	if augmentOptions.Copies > 0 {
+		opts.Wrap = func(factory models.Factory) models.Factory {
+			return augment.Factory(factory, augmentOptions, thesaurus)
+		}
+	}
+	candidates, err := tuning.Candidates(space, opts)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	fmt.Printf("Ajustando %s: busca %s com %d configurações, cross-validation aninhada %d×%d (%s)\n",
+		algorithm.Name, opts.Search, len(candidates), opts.OuterFolds, opts.InnerFolds, opts.Metric)
+	for _, param := range space {
+		if opts.Search == tuning.SearchRandom && param.Max > param.Min {
+			fmt.Printf("  %-14s [%g, %g]\n", param.Name, param.Min, param.Max)
+		} else {
+			fmt.Printf("  %-14s %s\n", param.Name, strings.Join(param.Values, ", "))
+		}
+	}
+
+	// Os classificadores imprimem o progresso do treinamento; durante a busca,
+	// apenas o contador de treinamentos é exibido (na saída de erro)
+	opts.Progress = func(done, total int) {
+		fmt.Fprintf(os.Stderr, "\rTreinamentos: %d/%d", done, total)
+	}
+	start := time.Now()
+	models.Verbose = false
+	result, err := tuning.Tune(docs, algorithm, space, opts)
+	models.Verbose = true
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Printf("LEADERBOARD - %s (%s, média ± desvio padrão em %d folds)\n", algorithm.Name, result.Metric, opts.InnerFolds)
+	fmt.Println(strings.Repeat("=", 80))
+	for i, trial := range result.Leaderboard {
+		if i >= *top {
+			fmt.Printf("... e mais %d configurações em %s\n", len(result.Leaderboard)-i, *leaderboardOut)
+			break
+		}
+		fmt.Printf("%3d. %.4f ± %.4f  %6.1fs  %s\n", i+1, trial.Mean, trial.Std, trial.Duration.Seconds(), trial.Params.Key())
+	}
+
+	fmt.Printf("\nCross-validation aninhada (%d folds externos):\n", opts.OuterFolds)
+	for i, fold := range result.Outer {
+		fmt.Printf("  Fold %d: %s %.4f (interno %.4f)  %s\n", i+1, result.Metric, fold.Score, fold.InnerScore, fold.Params.Key())
+	}
+	fmt.Printf("Estimativa do desempenho da busca: %s %.4f ± %.4f\n", result.Metric, result.NestedMean, result.NestedStd)
+
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Melhor configuração: %s (%s %.4f)\n", result.Best.Params.Key(), result.Metric, result.Best.Mean)
+	fmt.Printf("Tempo total: %s\n", time.Since(start).Round(time.Second))
+
+	if *leaderboardOut != "" {
+		file, err := os.Create(*leaderboardOut)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *leaderboardOut, err)
+		}
+		if err := tuning.WriteLeaderboard(file, result); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *leaderboardOut, err)
+		}
+		file.Close()
+		fmt.Printf("Leaderboard gravado em %s\n", *leaderboardOut)
+	}
+
+	if *out != "" {
+		config := tuning.NewConfig()
+		if _, err := os.Stat(*out); err == nil {
+			if config, err = tuning.LoadConfig(*out); err != nil {
+				log.Fatalf("Erro: %v", err)
+			}
+		}
+		config.Record(result, opts.Search)
+		if err := config.Save(*out); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		fmt.Printf("Configuração gravada em %s (use --config %s)\n", *out, *out)
+	}
+}
+
+// runExplain explica a predição de um classificador com o modelo local (estilo LIME)
+func runExplain(args []string, docs []models.Document) {
+	opts := explain.DefaultOptions()
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	algo := fs.String("algorithm", "nb", "algoritmo: nb, mlp, logreg, svm, knn, centroid ou ensemble")
+	fs.StringVar(&opts.Mode, "mode", opts.Mode, "features removidas nas perturbações: word ou sentence")
+	fs.IntVar(&opts.Samples, "samples", opts.Samples, "número de textos perturbados")
+	fs.IntVar(&opts.Top, "top", opts.Top, "número de features exibidas")
+	fs.Float64Var(&opts.KernelWidth, "kernel-width", opts.KernelWidth, "largura do kernel de proximidade (> 0)")
+	fs.StringVar(&opts.Target, "target", "", "classe explicada (padrão: classe prevista)")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "semente das perturbações")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para a explicação")
+		fmt.Println("Uso: go run cmd/classifier/main.go explain [--algorithm nb|mlp|logreg|svm|ensemble] [--mode word|sentence] [--samples 1000] <fonte>")
+		return
+	}
+	if opts.Mode != explain.ModeWord && opts.Mode != explain.ModeSentence {
+		log.Fatalf("Erro: modo inválido %q (use word ou sentence)", opts.Mode)
+	}
+	if opts.KernelWidth <= 0 {
+		log.Fatalf("Erro: --kernel-width deve ser maior que zero")
+	}
+	if opts.Target != "" {
+		checkClass("target", opts.Target, docs)
+	}
+
+	source := fs.Arg(0)
+	fmt.Printf("Analisando: %s\n", source)
+	content, err := input.Load(source, inputFormat)
+	if err != nil {
+		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	}
+	if strings.TrimSpace(content.Text) == "" {
+		fmt.Println("Não foi possível extrair texto relevante.")
+		return
+	}
+
+	algorithm := algorithmName(*algo)
+	fmt.Printf("Treinando classificador %s...\n", algorithm)
+	classifier := newClassifier(algorithm)
+	classifier.Train(docs)
+
+	fmt.Printf("Gerando %d perturbações (%s)...\n", opts.Samples, opts.Mode)
+	explanation, err := explain.Explain(explain.FromClassifier(classifier), content.Text, opts)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("EXPLICAÇÃO LOCAL (LIME)")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Fonte analisada: %s (%s)\n", content.Origin, content.Format)
+	fmt.Printf("Algoritmo: %s | Classe explicada: %s\n", algorithm, displayLabel(explanation.Target))
+	fmt.Printf("P(%s) original: %.2f%% | sem as features: %.2f%% | R² do modelo local: %.3f\n",
+		explanation.Target, explanation.Original, explanation.Intercept, explanation.Score)
+	fmt.Printf("\n%-12s %s\n", "Peso (p.p.)", "Feature")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, feature := range explanation.Features {
+		name := feature.Name
+		if len([]rune(name)) > 64 {
+			name = string([]rune(name)[:61]) + "..."
+		}
+		fmt.Printf("%+-12.3f %s\n", feature.Weight, name)
+	}
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Pesos positivos aumentam P(%s) quando a feature está presente.\n", explanation.Target)
+}
+
+// stringList é uma flag que pode ser repetida
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// compilePatterns compila uma lista de expressões regulares
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Fatalf("Expressão regular inválida %q: %v", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// runCrawl coleta artigos a partir de páginas índice e grava documentos candidatos (CSV, TSV ou JSONL)
+func runCrawl(args []string) {
+	fs := flag.NewFlagSet("crawl", flag.ExitOnError)
+	var seeds, follow, articles stringList
+	fs.Var(&seeds, "seed", "página inicial (pode ser repetida)")
+	fs.Var(&follow, "follow", "regex de links a seguir, como índices e paginação (pode ser repetida)")
+	fs.Var(&articles, "article", "regex de links de artigos a extrair (pode ser repetida)")
+	depth := fs.Int("depth", 2, "profundidade máxima a partir das sementes")
+	maxPages := fs.Int("max-pages", 100, "número máximo de páginas baixadas")
+	workers := fs.Int("workers", 4, "número de downloads simultâneos")
+	minChars := fs.Int("min-chars", 300, "tamanho mínimo do texto de um artigo")
+	sameHost := fs.Bool("same-host", true, "seguir apenas links do mesmo host das sementes")
+	delay := fs.Duration("delay", 500*time.Millisecond, "pausa de cada worker entre requisições")
+	label := fs.String("label", "fake", "rótulo dos artigos coletados (fake, true ou outro rótulo, ex.: satire)")
+	out := fs.String("out", "candidatos.csv", "arquivo de saída (.csv no formato do FakeTrue.Br, .tsv ou .jsonl)")
+	fs.Parse(args)
+
+	seeds = append(seeds, fs.Args()...)
+	if len(seeds) == 0 {
+		fmt.Println("Erro: ao menos uma página inicial é necessária")
+		fmt.Println("Uso: go run cmd/classifier/main.go crawl [opções] --seed <url> [--article <regex>] [--follow <regex>]")
+		return
+	}
+	// Validar o rótulo antes da coleta, que pode levar minutos
+	if strings.TrimSpace(*label) == "" {
+		log.Fatalf("Erro: --label não pode ser vazio")
+	}
+
+	cfg := crawler.CrawlConfig{
+		Seeds:    seeds,
+		Follow:   compilePatterns(follow),
+		Articles: compilePatterns(articles),
+		MaxDepth: *depth,
+		MaxPages: *maxPages,
+		Workers:  *workers,
+		MinChars: *minChars,
+		SameHost: *sameHost,
+		Delay:    *delay,
+	}
+
+	fmt.Printf("Coletando artigos a partir de %d semente(s) (profundidade %d, até %d páginas)...\n",
+		len(seeds), *depth, *maxPages)
+	found, errs := crawler.Crawl(cfg)
+	for _, err := range errs {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	docs, err := dataset.FromArticles(found, *label)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Erro ao criar %s: %v", *out, err)
+	}
+	defer file.Close()
+
+	if err := dataset.Write(file, *out, docs, dataset.FormatAuto); err != nil {
+		log.Fatalf("Erro ao gravar %s: %v", *out, err)
+	}
+
+	fmt.Printf("\n%d artigos gravados em %s com rótulo %q (%d erros)\n", len(docs), *out, *label, len(errs))
+	fmt.Println("Revise os documentos antes de incorporá-los ao dataset de treinamento.")
+}
+
+// runConvert grava o dataset carregado (--dataset) em outro formato
+func runConvert(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	out := fs.String("out", "", "arquivo de saída")
+	format := fs.String("format", dataset.FormatAuto, "formato de saída: auto (pela extensão), faketrue, csv, tsv ou jsonl")
+	fs.Parse(args)
+
+	if *out == "" {
+		fmt.Println("Erro: arquivo de saída necessário")
+		fmt.Println("Uso: go run cmd/classifier/main.go [--dataset <origem>] convert --out <arquivo> [--format jsonl]")
+		return
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Erro ao criar %s: %v", *out, err)
+	}
+	defer file.Close()
+
+	if err := dataset.Write(file, *out, docs, *format); err != nil {
+		log.Fatalf("Erro ao gravar %s: %v", *out, err)
+	}
+
+	counts := make(map[string]int)
+	for _, doc := range docs {
+		counts[doc.Label]++
+	}
+	var parts []string
+	for _, label := range orderedClasses(counts) {
+		parts = append(parts, fmt.Sprintf("%s: %d", label, counts[label]))
+	}
+	fmt.Printf("%d documentos gravados em %s (%s)\n", len(docs), *out, strings.Join(parts, ", "))
+}
+
+// similarResult é a saída JSON do comando similar
+type similarResult struct {
+	Source  string                          `json:"source"`
+	Scoring string                          `json:"scoring"`
+	Similar map[string][]retrieval.Evidence `json:"similar"`
+}
+
+// runSimilar busca as notícias do corpus mais semelhantes a uma fonte, por classe
+func runSimilar(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("similar", flag.ExitOnError)
+	k := fs.Int("k", 5, "número de notícias semelhantes por classe")
+	asJSON := fs.Bool("json", false, "imprime o resultado em JSON")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Println("Erro: URL, arquivo ou - (stdin) necessário")
+		fmt.Println("Uso: go run cmd/classifier/main.go [--retrieval tfidf|bm25] similar [--k 5] [--json] <fonte>")
+		return
+	}
+
+	content, err := input.Load(fs.Arg(0), inputFormat)
+	if err != nil {
+		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	}
+
+	if !*asJSON {
+		similarCount = *k
+		fmt.Printf("Analisando: %s (%s)\n", content.Origin, content.Format)
+		printNearCopies(docs, content.Text)
+		printSimilarArticles(docs, content.Text)
+		return
+	}
+
+	index, err := retrieval.NewIndex(docs, retrievalOptions)
+	if err != nil {
+		log.Fatalf("Erro no índice de notícias semelhantes: %v", err)
+	}
+	result := similarResult{Source: content.Origin, Scoring: retrievalOptions.Scoring, Similar: make(map[string][]retrieval.Evidence)}
+	for label, hits := range index.SearchByLabel(content.Text, *k) {
+		for _, hit := range hits {
+			result.Similar[label] = append(result.Similar[label], hit.Evidence())
+		}
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Fatalf("Erro ao gerar JSON: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// runStyle mostra as características de estilo de uma notícia comparadas ao perfil
+// médio de cada classe do corpus; com --out, grava as características de todo o dataset
+func runStyle(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("style", flag.ExitOnError)
+	out := fs.String("out", "", "grava as características de estilo de cada documento do dataset em CSV")
+	fs.Parse(args)
+
+	// Títulos em só parte das classes (no FakeTrue.Br, só nas falsas) separariam as classes
+	if untitled := stylometry.UntitledClasses(docs); len(untitled) > 0 && len(untitled) < len(models.Labels(docs)) {
+		fmt.Printf("[AVISO] Características do título omitidas: a(s) classe(s) %s não têm títulos no corpus\n", strings.Join(untitled, ", "))
+	}
+
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *out, err)
+		}
+		if err := stylometry.WriteCSV(file, docs); err != nil {
+			file.Close()
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		if err := file.Close(); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		fmt.Printf("Características de estilo de %d documentos gravadas em %s\n", len(docs), *out)
+	}
+
+	if fs.NArg() < 1 {
+		if *out == "" {
+			fmt.Println("Erro: URL, arquivo ou - (stdin) necessário")
+			fmt.Println("Uso: go run cmd/classifier/main.go style [--out estilo.csv] [<fonte>]")
+		}
+		return
+	}
+
+	content, err := input.Load(fs.Arg(0), inputFormat)
+	if err != nil {
+		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	}
+	values := stylometry.Extract(content.Text)
+	profiles := stylometry.Profiles(docs)
+	texts, _ := models.Samples(docs)
+	var scaler stylometry.Scaler
+	scaler.Fit(texts)
+
+	fmt.Printf("Analisando: %s (%s)\n", content.Origin, content.Format)
+	fmt.Printf("\n%-16s %9s", "Característica", "Valor")
+	for _, profile := range profiles {
+		fmt.Printf(" %9s", profile.Label)
+	}
+	fmt.Printf("  %-10s %s\n", "Próxima", "Descrição")
+
+	distances := make([]float64, len(profiles))
+	for i, feature := range stylometry.Features {
+		fmt.Printf("%-16s %9.3f", feature.Name, values[i])
+		closest, best, worst := "-", math.Inf(1), 0.0
+		for p, profile := range profiles {
+			fmt.Printf(" %9.3f", profile.Mean[i])
+			z := math.Abs(values[i]-profile.Mean[i]) / scaler.Scale[i]
+			distances[p] += z / float64(len(stylometry.Features))
+			if z < best {
+				closest, best = profile.Label, z
+			}
+			worst = math.Max(worst, z)
+		}
+		if worst-best < 1e-9 {
+			closest = "-" // característica não distingue as classes
+		}
+		fmt.Printf("  %-10s %s\n", closest, feature.Description)
+	}
+
+	fmt.Println("\nDistância média ao perfil de cada classe (em desvios padrão do corpus):")
+	for p, profile := range profiles {
+		fmt.Printf("  %-10s %.2f (%d documentos)\n", profile.Label, distances[p], profile.Count)
+	}
+}
+
+// runTopics treina um modelo de tópicos (LDA) no dataset e mostra as palavras de cada
+// tópico, a presença dos tópicos em cada classe e, com uma fonte, a mistura da notícia
+func runTopics(args []string, docs []models.Document) {
+	opts := topics.DefaultOptions()
+	fs := flag.NewFlagSet("topics", flag.ExitOnError)
+	fs.IntVar(&opts.Topics, "k", opts.Topics, "número de tópicos")
+	fs.IntVar(&opts.Iterations, "iterations", opts.Iterations, "iterações do amostrador de Gibbs")
+	fs.Float64Var(&opts.Alpha, "alpha", 0, "prior de Dirichlet das misturas dos documentos (0 = 1/k)")
+	fs.Float64Var(&opts.Beta, "beta", opts.Beta, "prior de Dirichlet das palavras dos tópicos")
+	fs.IntVar(&opts.MinCount, "min-count", opts.MinCount, "frequência mínima de uma palavra")
+	fs.Float64Var(&opts.MaxDocFreq, "max-df", opts.MaxDocFreq, "fração máxima de documentos com a palavra (1 = sem limite)")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "semente do amostrador")
+	words := fs.Int("words", 10, "palavras exibidas por tópico")
+	fs.Parse(args)
+
+	texts, labels := models.Samples(docs)
+	fmt.Printf("Treinando LDA com %d tópicos (%d iterações) em %d documentos...\n", opts.Topics, opts.Iterations, len(texts))
+	model, err := topics.Train(texts, opts)
+	if err != nil {
+		log.Fatalf("Erro no modelo de tópicos: %v", err)
+	}
+	profile := topics.NewProfile(model.Theta, labels)
+	fmt.Printf("Vocabulário: %d palavras | α = %.3g, β = %.3g\n", len(model.Words), model.Alpha, model.Beta)
+
+	fmt.Println("\n" + strings.Repeat("=", 100))
+	fmt.Println("TÓPICOS (mistura média por classe e sobrerrepresentação P(classe | tópico) / P(classe))")
+	fmt.Println(strings.Repeat("=", 100))
+	for t := 0; t < model.Topics; t++ {
+		var top []string
+		for _, word := range model.TopWords(t, *words) {
+			top = append(top, word.Word)
+		}
+		fmt.Printf("Tópico %2d: %s\n", t+1, strings.Join(top, ", "))
+		var classes []string
+		for _, class := range orderedClasses(profile.Base) {
+			classes = append(classes, fmt.Sprintf("%s %5.1f%% (×%.2f)", classLabel(class), profile.Mixture[class][t]*100, profile.Lift(class, t)))
+		}
+		fmt.Printf("           %s\n", strings.Join(classes, " | "))
+	}
+
+	if fs.NArg() < 1 {
+		return
+	}
+	content, err := input.Load(fs.Arg(0), inputFormat)
+	if err != nil {
+		log.Fatalf("Erro ao extrair o conteúdo da notícia: %v", err)
+	}
+	mixture, tokens := model.Infer(content.Text)
+	fmt.Printf("\nMistura de tópicos de %s (%d palavras no vocabulário do modelo):\n", content.Origin, tokens)
+	if tokens == 0 {
+		fmt.Println("  Nenhuma palavra da notícia está no vocabulário do modelo.")
+		return
+	}
+	for i, t := range topics.Dominant(mixture) {
+		if i >= 3 || (i > 0 && mixture[t] < 0.1) {
+			break
+		}
+		var top []string
+		for _, word := range model.TopWords(t, 5) {
+			top = append(top, word.Word)
+		}
+		fmt.Printf("  Tópico %2d  %5.1f%%  %s\n", t+1, mixture[t]*100, strings.Join(top, ", "))
+		for _, class := range orderedClasses(profile.Base) {
+			lift := profile.Lift(class, t)
+			note := ""
+			if lift >= 1.2 {
+				note = "  ← sobrerrepresentado"
+			}
+			fmt.Printf("             P(%s | tópico) = %5.1f%% (×%.2f)%s\n", classLabel(class), profile.Share[class][t]*100, lift, note)
+		}
+	}
+
+	// P(classe | tópicos da notícia): média de P(classe | tópico) ponderada pela mistura
+	expected := make(map[string]float64)
+	for _, class := range orderedClasses(profile.Base) {
+		for t, p := range mixture {
+			expected[class] += p * profile.Share[class][t] * 100
+		}
+	}
+	fmt.Printf("Classes esperadas pelos tópicos da notícia: %s\n", formatProbabilities(expected))
+}
+
+// runStats imprime estatísticas do dataset carregado, em texto ou JSON; records são os
+// registros do FakeTrue.Br da mesma leitura do dataset (nil em outros formatos)
+func runStats(args []string, docs []models.Document, records []models.NewsRecord) {
+	opts := stats.DefaultOptions()
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	fs.IntVar(&opts.Top, "top", opts.Top, "termos exibidos por classe")
+	fs.IntVar(&opts.Bins, "bins", opts.Bins, "faixas do histograma de tamanhos")
+	asJSON := fs.Bool("json", false, "imprime as estatísticas em JSON")
+	fs.Parse(args)
+
+	summary := stats.Summarize(docs, records, opts)
+
+	if *asJSON {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			log.Fatalf("Erro ao gerar JSON: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println(strings.Repeat("=", 100))
+	fmt.Printf("ESTATÍSTICAS DO DATASET - %s\n", datasetSource)
+	fmt.Println(strings.Repeat("=", 100))
+	fmt.Printf("Documentos: %d (%d com texto e rótulo) | Tokens: %d | Vocabulário: %d palavras\n\n",
+		summary.Documents, summary.Usable, summary.Tokens, summary.Vocabulary)
+
+	fmt.Printf("%-14s %6s %7s %8s %7s   %s\n", "Classe", "Docs", "Fração", "Tokens", "Vocab.", "Palavras por documento")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, class := range summary.Classes {
+		fmt.Printf("%-14s %6d %6.1f%% %8d %7d   %s\n", classLabel(class.Label), class.Documents, class.Share*100,
+			class.Tokens, class.Vocabulary, formatDistribution(class.Words))
+	}
+	fmt.Printf("%-14s %6d %6.1f%% %8d %7d   %s\n", "(todas)", summary.Usable, 100.0, summary.Tokens, summary.Vocabulary,
+		formatDistribution(summary.Words))
+	fmt.Printf("\nCaracteres por documento:\n")
+	for _, class := range summary.Classes {
+		fmt.Printf("  %-12s %s\n", classLabel(class.Label), formatDistribution(class.Characters))
+	}
+	fmt.Printf("  %-12s %s\n", "(todas)", formatDistribution(summary.Characters))
+
+	if len(summary.Histogram) > 0 {
+		fmt.Printf("\nHistograma de palavras por documento:\n")
+		fmt.Printf("  %-13s", "Faixa")
+		for _, class := range summary.Classes {
+			fmt.Printf(" %10s", classLabel(class.Label))
+		}
+		fmt.Println()
+		for i, bin := range summary.Histogram {
+			upper := bin.Upper - 1
+			if i == len(summary.Histogram)-1 {
+				upper = summary.Words.Max
+			}
+			fmt.Printf("  %5d - %-5d", bin.Lower, upper)
+			total := 0
+			for _, class := range summary.Classes {
+				fmt.Printf(" %10d", bin.Counts[class.Label])
+				total += bin.Counts[class.Label]
+			}
+			fmt.Printf("  %s\n", strings.Repeat("█", int(math.Ceil(float64(total)*40/math.Max(float64(summary.Usable), 1)))))
+		}
+	}
+
+	fmt.Printf("\nTermos mais frequentes por classe:\n")
+	for _, class := range summary.Classes {
+		var terms []string
+		for _, term := range class.Frequent {
+			terms = append(terms, fmt.Sprintf("%s (%d)", term.Term, term.Count))
+		}
+		fmt.Printf("  %s: %s\n", classLabel(class.Label), strings.Join(terms, ", "))
+	}
+	fmt.Printf("\nTermos característicos por classe (razão de log-odds com prior de Dirichlet, escore z):\n")
+	for _, class := range summary.Classes {
+		var terms []string
+		for _, term := range class.LogOdds {
+			terms = append(terms, fmt.Sprintf("%s (%.2f)", term.Term, term.Score))
+		}
+		fmt.Printf("  %s: %s\n", classLabel(class.Label), strings.Join(terms, ", "))
+	}
+
+	var missing []string
+	for _, field := range stats.DocumentFields {
+		missing = append(missing, fmt.Sprintf("%s %d", field, summary.Missing[field]))
+	}
+	fmt.Printf("\nCampos vazios nos documentos: %s\n", strings.Join(missing, " | "))
+	if summary.Records != nil {
+		var empty []string
+		for _, column := range stats.RecordColumns {
+			empty = append(empty, fmt.Sprintf("%s %d", column, summary.Records.Empty[column]))
+		}
+		fmt.Printf("Colunas vazias nos %d pares do FakeTrue.Br: %s\n", summary.Records.Records, strings.Join(empty, " | "))
+	}
+}
+
+// formatDistribution formata o resumo de uma distribuição de tamanhos
+func formatDistribution(d stats.Distribution) string {
+	return fmt.Sprintf("mín %d | p10 %d | p25 %d | mediana %d | p75 %d | p90 %d | máx %d | média %.1f ± %.1f",
+		d.Min, d.P10, d.P25, d.Median, d.P75, d.P90, d.Max, d.Mean, d.Std)
+}
+
+// runVocab seleciona o vocabulário do Naive Bayes ou do MLP com os hiperparâmetros
+// configurados, como no treinamento com todo o dataset, e o imprime ou grava em CSV
+func runVocab(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("vocab", flag.ExitOnError)
+	algo := fs.String("algorithm", "nb", "algoritmo cujo vocabulário é selecionado: nb ou mlp")
+	top := fs.Int("top", 30, "termos exibidos")
+	out := fs.String("out", "", "grava o vocabulário selecionado em CSV")
+	fs.Parse(args)
+
+	algorithm := algorithmName(*algo)
+	sizeParam := map[string]string{"Naive Bayes": "features", "MLP": "vocab"}[algorithm]
+	if sizeParam == "" {
+		log.Fatalf("Erro: o vocabulário selecionado é usado apenas por nb e mlp")
+	}
+	tunable, _ := tuning.Lookup(algorithm)
+	params := tunable.Defaults.Merge(hyperparameters.Params(algorithm))
+	if dim := params.Int("hashing", 0); dim > 0 || params.String("input", "") == mlp.InputHashing {
+		log.Fatalf("Erro: com hashing o %s não tem vocabulário (os termos vão direto para um espaço de dimensão fixa)", algorithm)
+	}
+	opts, err := tuning.SelectionOptions(params, sizeParam)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	texts, labels := models.Samples(docs)
+	vocab, err := selection.Select(texts, labels, opts)
+	if err != nil {
+		log.Fatalf("Erro na seleção do vocabulário: %v", err)
+	}
+	fmt.Printf("Vocabulário do %s: %d de %d termos (seleção %s, min_df %d, max_df %g, %d documentos)\n",
+		algorithm, len(vocab.Terms), vocab.Candidates, opts.Method, opts.MinDF, opts.MaxDF, vocab.Documents)
+
+	fmt.Printf("\n%-5s %-20s %12s %8s %6s", "Pos.", "Termo", opts.Method, "Ocorr.", "Docs")
+	for _, class := range vocab.Classes {
+		fmt.Printf(" %14s", "df "+classLabel(class))
+	}
+	fmt.Println()
+	for i, term := range vocab.Terms {
+		if i >= *top {
+			fmt.Printf("... e mais %d termos\n", len(vocab.Terms)-i)
+			break
+		}
+		fmt.Printf("%-5d %-20s %12.4f %8d %6d", i+1, term.Term, term.Score, term.Count, term.DF)
+		for _, class := range vocab.Classes {
+			fmt.Printf(" %14d", term.ClassDF[class])
+		}
+		fmt.Println()
+	}
+
+	if *out != "" {
+		if err := vocab.Save(*out); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		fmt.Printf("\nVocabulário gravado em %s\n", *out)
+	}
+}
+
+// runEmbeddings treina vetores de palavras no dataset (ou lê vetores pré-treinados),
+// grava os vetores e mostra as palavras mais próximas das palavras consultadas
+func runEmbeddings(args []string, docs []models.Document) {
+	opts := embeddings.DefaultOptions()
+	fs := flag.NewFlagSet("embeddings", flag.ExitOnError)
+	fs.StringVar(&opts.Method, "method", opts.Method, "método de treinamento: "+strings.Join(embeddings.Methods, " ou "))
+	fs.IntVar(&opts.Dim, "dim", opts.Dim, "dimensão dos vetores")
+	fs.IntVar(&opts.Window, "window", opts.Window, "tamanho da janela de contexto")
+	fs.IntVar(&opts.Epochs, "epochs", opts.Epochs, "épocas de treinamento")
+	fs.IntVar(&opts.MinCount, "min-count", opts.MinCount, "frequência mínima de uma palavra")
+	fs.Float64Var(&opts.LearningRate, "learning-rate", 0, "taxa de aprendizado inicial (0 = padrão do método)")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "semente do treinamento")
+	vectorsPath := fs.String("vectors", "", "lê vetores pré-treinados em vez de treinar")
+	out := fs.String("out", "", "arquivo de saída dos vetores (formato texto do word2vec)")
+	var words stringList
+	fs.Var(&words, "neighbors", "palavra cujas vizinhas serão exibidas (pode repetir)")
+	top := fs.Int("top", 10, "número de vizinhas exibidas por palavra")
+	fs.Parse(args)
+
+	var vectors *embeddings.Vectors
+	var err error
+	if *vectorsPath != "" {
+		vectors, err = embeddings.LoadFile(*vectorsPath)
+	} else {
+		texts, _ := models.Samples(docs)
+		fmt.Printf("Treinando embeddings %s (%d dimensões, janela %d) com %d documentos...\n", opts.Method, opts.Dim, opts.Window, len(texts))
+		vectors, err = embeddings.Train(texts, opts)
+	}
+	if err != nil {
+		log.Fatalf("Erro nos embeddings: %v", err)
+	}
+	fmt.Printf("%d palavras com vetores de %d dimensões\n", vectors.Len(), vectors.Dim)
+
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		neighbors := vectors.Similar(word, *top)
+		if neighbors == nil {
+			fmt.Printf("\n%s: palavra fora do vocabulário\n", word)
+			continue
+		}
+		fmt.Printf("\nPalavras mais próximas de %s:\n", word)
+		for _, neighbor := range neighbors {
+			fmt.Printf("  %-20s %.4f\n", neighbor.Word, neighbor.Similarity)
+		}
+	}
+
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *out, err)
+		}
+		defer file.Close()
+		if err := vectors.Save(file); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		fmt.Printf("Vetores gravados em %s (use --embeddings %s --mlp-input embedding)\n", *out, *out)
+	}
+}
+
+// runDedup agrupa os documentos quase-duplicados do dataset, imprime os maiores
+// clusters e, opcionalmente, grava o relatório e o dataset limpo
+func runDedup(args []string, docs []models.Document) {
+	fs := flag.NewFlagSet("dedup", flag.ExitOnError)
+	out := fs.String("out", "", "arquivo do dataset limpo (um documento por cluster)")
+	format := fs.String("format", dataset.FormatAuto, "formato do dataset limpo: auto (pela extensão), faketrue, csv, tsv ou jsonl")
+	reportPath := fs.String("report", "", "arquivo CSV com todos os clusters de quase-duplicados")
+	keep := fs.String("keep", neardup.KeepFirst, "documento mantido de cada cluster: first ou longest")
+	dropConflicts := fs.Bool("drop-conflicts", false, "descarta inteiros os clusters que misturam rótulos")
+	top := fs.Int("top", 10, "número de clusters exibidos")
+	fs.Parse(args)
+
+	fmt.Printf("Procurando quase-duplicados em %d documentos (Jaccard ≥ %.2f)...\n", len(docs), duplicateOptions.Threshold)
+	result, err := neardup.Dedup(docs, duplicateOptions, *keep, *dropConflicts)
+	if err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+
+	duplicated, conflicting := 0, 0
+	for _, cluster := range result.Clusters {
+		duplicated += len(cluster.Members)
+		if cluster.Conflicting() {
+			conflicting++
+		}
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("QUASE-DUPLICADOS NO DATASET")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Clusters: %d (%d documentos, %d com rótulos diferentes)\n", len(result.Clusters), duplicated, conflicting)
+	fmt.Printf("Documentos removidos: %d de %d", result.Removed, len(docs))
+	if result.DroppedCluster > 0 {
+		fmt.Printf(" (%d clusters descartados por conflito de rótulos)", result.DroppedCluster)
+	}
+	fmt.Println()
+
+	for c, cluster := range result.Clusters {
+		if c >= *top {
+			fmt.Printf("... e mais %d clusters\n", len(result.Clusters)-c)
+			break
+		}
+		fmt.Printf("\nCluster %d: %d documentos, Jaccard %.2f-%.2f, rótulos %s", c+1, len(cluster.Members),
+			cluster.MinSimilarity, cluster.MaxSimilarity, strings.Join(cluster.Labels, "/"))
+		if cluster.Conflicting() {
+			fmt.Print(" [CONFLITO]")
+		}
+		fmt.Println()
+		for _, member := range cluster.Members {
+			doc := docs[member]
+			marker := " "
+			if member == cluster.Kept && !cluster.Dropped {
+				marker = "*"
+			}
+			fmt.Printf("  %s %-14s %-8s %s\n", marker, truncate(doc.ID, 14), shortLabel(doc.Label), truncate(strings.Join(strings.Fields(doc.Text), " "), 60))
+		}
+	}
+	if len(result.Clusters) > 0 {
+		fmt.Println("\n* = documento mantido no dataset limpo")
+	}
+
+	if *reportPath != "" {
+		file, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *reportPath, err)
+		}
+		defer file.Close()
+		if err := neardup.WriteReport(file, docs, result); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *reportPath, err)
+		}
+		fmt.Printf("Relatório gravado em %s\n", *reportPath)
+	}
+
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Erro ao criar %s: %v", *out, err)
+		}
+		defer file.Close()
+		if err := dataset.Write(file, *out, result.Kept, *format); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+		fmt.Printf("Dataset limpo gravado em %s (%d documentos)\n", *out, len(result.Kept))
+	}
+}
+
+// printUsage imprime as instruções de uso
+func printUsage() {
+	fmt.Println("Uso:")
+	fmt.Println("  go run cmd/classifier/main.go [opções] <fonte>                   # Compara todos os algoritmos (com cross-validation)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] mlp <fonte>               # Usa apenas MLP")
+	fmt.Println("  go run cmd/classifier/main.go [opções] nb <fonte>                # Usa apenas Naive Bayes")
+	fmt.Println("  go run cmd/classifier/main.go [opções] logreg <fonte>            # Usa apenas regressão logística")
+	fmt.Println("  go run cmd/classifier/main.go [opções] svm <fonte>               # Usa apenas SVM linear")
+	fmt.Println("  go run cmd/classifier/main.go [opções] knn <fonte>               # Usa apenas k-vizinhos mais próximos")
+	fmt.Println("  go run cmd/classifier/main.go [opções] centroid <fonte>          # Usa apenas centroide mais próximo (Rocchio)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] ensemble <fonte>          # Combina NB, MLP e heurística")
+	fmt.Println("  go run cmd/classifier/main.go [opções] fast <fonte>              # Comparação rápida (sem cross-validation)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] segment <fonte>           # Classifica cada sentença/parágrafo e destaca trechos")
+	fmt.Println("  go run cmd/classifier/main.go [opções] explain <fonte>           # Explica a predição com modelo local (LIME)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] evaluate                  # Cross-validation com métricas e calibração")
+	fmt.Println("  go run cmd/classifier/main.go [opções] tune                      # Busca de hiperparâmetros (cross-validation aninhada)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] convert --out <arquivo>   # Converte o dataset (CSV, TSV, JSONL)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] stats [--json]            # Estatísticas do dataset")
+	fmt.Println("  go run cmd/classifier/main.go [opções] vocab [--out <arquivo>]   # Vocabulário selecionado do NB ou do MLP")
+	fmt.Println("  go run cmd/classifier/main.go [opções] dedup [--out <arquivo>]   # Agrupa e remove quase-duplicados do dataset")
+	fmt.Println("  go run cmd/classifier/main.go [opções] similar <fonte>           # Notícias semelhantes do corpus, por classe")
+	fmt.Println("  go run cmd/classifier/main.go [opções] embeddings [--out <arq>]  # Treina vetores de palavras (word2vec/GloVe)")
+	fmt.Println("  go run cmd/classifier/main.go [opções] style <fonte>             # Características de estilo comparadas às classes")
+	fmt.Println("  go run cmd/classifier/main.go [opções] topics [<fonte>]          # Tópicos (LDA) por classe e mistura da notícia")
+	fmt.Println("  go run cmd/classifier/main.go [opções] cache-import <arquivos>   # Importa páginas HTML salvas para o cache")
+	fmt.Println("  go run cmd/classifier/main.go [opções] crawl --seed <url> ...    # Coleta artigos seguindo links")
+	fmt.Println("")
+	fmt.Println("A fonte pode ser uma URL, um arquivo local (HTML, texto ou PDF) ou - para ler da entrada padrão.")
+	fmt.Println("")
+	fmt.Println("Opções:")
+	flag.PrintDefaults()
+	fmt.Println("")
+	fmt.Println("Exemplos:")
+	fmt.Println("  go run cmd/classifier/main.go https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go mlp https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go fast https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go nb noticia.pdf")
+	fmt.Println("  go run cmd/classifier/main.go segment --level paragraph --html relatorio.html https://g1.globo.com/...")
+	fmt.Println("  cat noticia.txt | go run cmd/classifier/main.go --format text nb -")
+	fmt.Println("  go run cmd/classifier/main.go --calibration isotonic nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --penalty l1 logreg https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --ensemble stacking ensemble https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go evaluate --algorithm mlp --folds 5 --bins 10")
+	fmt.Println("  go run cmd/classifier/main.go --group-duplicates --dup-threshold 0.7 evaluate")
+	fmt.Println("  go run cmd/classifier/main.go tune --algorithm mlp --search random --trials 30 --out config.json")
+	fmt.Println("  go run cmd/classifier/main.go --config config.json mlp https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --dataset rotulado.csv evaluate --algorithm logreg")
+	fmt.Println("  go run cmd/classifier/main.go --dataset corpus/ nb noticia.txt          # um subdiretório por rótulo")
+	fmt.Println("  go run cmd/classifier/main.go convert --out faketrue.jsonl")
+	fmt.Println("  go run cmd/classifier/main.go --dataset rotulado.csv stats --top 20 --json")
+	fmt.Println("  go run cmd/classifier/main.go --selection chi2 --features 500 --max-df 0.5 vocab --algorithm mlp --out vocab.csv")
+	fmt.Println("  go run cmd/classifier/main.go --selection ig --features 300 evaluate --algorithm nb")
+	fmt.Println("  go run cmd/classifier/main.go --hashing 4096 --ngrams 2 evaluate --algorithm mlp")
+	fmt.Println("  go run cmd/classifier/main.go --hashing 262144 --ngrams 3 nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --augment 2 --augment-strength 0.15 evaluate --algorithm mlp")
+	fmt.Println("  go run cmd/classifier/main.go --augment 3 --thesaurus sinonimos.txt --augment-ops synonym,delete mlp https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --retrieval bm25 similar --k 5 --json https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go embeddings --method glove --dim 100 --out vetores.txt --neighbors governo")
+	fmt.Println("  go run cmd/classifier/main.go --mlp-input embedding --embeddings vetores.txt mlp https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --stylometry evaluate --algorithm logreg")
+	fmt.Println("  go run cmd/classifier/main.go --source-prior --source-list fontes.txt nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --source-prior evaluate --algorithm nb")
+	fmt.Println("  go run cmd/classifier/main.go style --out estilo.csv https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go topics --k 20 --iterations 500 https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --dup-threshold 0.7 dedup --report duplicados.csv --out limpo.csv")
+	fmt.Println("  go run cmd/classifier/main.go --min-confidence 70 --threshold fake=65 nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go --cost true=5 --cost fake=1 --abstain-cost 0.5 evaluate")
+	fmt.Println("  go run cmd/classifier/main.go --offline nb https://g1.globo.com/...")
+	fmt.Println("  go run cmd/classifier/main.go cache-import --url https://g1.globo.com/... pagina.html")
+	fmt.Println("  go run cmd/classifier/main.go crawl --seed https://www.boatos.org/ --article 'boatos\\.org/.+\\.html$' --out boatos.csv")
+}
+
+// main é o ponto de entrada da aplicação
+func main() {
+	crawlerOptions := crawler.RegisterFlags(flag.CommandLine)
+	flag.StringVar(&datasetSource, "dataset", dataset.DefaultURL, "URL, arquivo ou diretório do dataset de treinamento")
+	flag.StringVar(&datasetFormat, "dataset-format", dataset.FormatAuto, "formato do dataset: "+strings.Join(dataset.Formats, ", "))
+	flag.StringVar(&inputFormat, "format", input.FormatAuto, "formato da entrada: auto, html, text ou pdf")
+	flag.StringVar(&calibrationMethod, "calibration", calibration.MethodNone, "calibração das probabilidades: none, platt, isotonic ou temperature")
+	var thresholds, costs stringList
+	minConfidence := flag.Float64("min-confidence", decisionPolicy.MinProbability, "probabilidade mínima (%) para emitir um veredito; abaixo disso o resultado é inconclusivo")
+	flag.Var(&thresholds, "threshold", "probabilidade mínima por classe, no formato classe=% (pode repetir, ex.: fake=70)")
+	flag.Var(&costs, "cost", "custo de um erro ao prever a classe, no formato classe=custo (pode repetir, ex.: true=5)")
+	configPath := flag.String("config", "", "arquivo de hiperparâmetros gerado pelo comando tune")
+	penalty := flag.String("penalty", linear.PenaltyL2, "regularização da regressão logística: l1 ou l2")
+	lambda := flag.Float64("lambda", 0, "força da regularização dos modelos lineares (0 = padrão do modelo)")
+	var weights stringList
+	flag.StringVar(&ensembleMethod, "ensemble", ensemble.MethodWeighted, "combinação do ensemble: soft, weighted ou stacking")
+	flag.Var(&weights, "ensemble-weight", "peso fixo (> 0) de um membro do ensemble weighted, no formato membro=peso (nb, mlp ou heuristic; pode repetir)")
+	mlpInput := flag.String("mlp-input", mlp.InputBagOfWords, "entrada do MLP: bow (presença de palavras), embedding (embedding do documento) ou hashing (presença de termos por hashing)")
+	embeddingMethod := flag.String("embedding-method", embeddings.MethodSkipGram, "treinamento dos embeddings do MLP: sgns (word2vec) ou glove")
+	embeddingDim := flag.Int("embedding-dim", 50, "dimensão dos embeddings treinados para o MLP")
+	embeddingPooling := flag.String("embedding-pooling", embeddings.WeightingTFIDF, "embedding do documento no MLP: mean (média dos vetores) ou tfidf (média ponderada por TF-IDF)")
+	flag.BoolVar(&sourcePrior, "source-prior", false, "combina a reputação do domínio da notícia (links do corpus e --source-list) com os modelos de texto")
+	flag.Float64Var(&reputationOptions.Weight, "source-weight", reputationOptions.Weight, "peso do prior de fonte na combinação (expoente da razão de probabilidades)")
+	flag.StringVar(&reputationOptions.Untrusted, "untrusted-label", reputationOptions.Untrusted, "rótulo das notícias não confiáveis, favorecido pela heurística de termos e pelas fontes deny da --source-list")
+	sourceList := flag.String("source-list", "", "lista de reputação: linhas \"allow <domínio>\", \"deny <domínio>\" ou \"<rótulo> <domínio>\"")
+	flag.StringVar(&sourceURL, "source-url", "", "link da notícia quando a fonte é um arquivo ou o stdin (para a reputação da fonte)")
+	selectionMethod := flag.String("selection", selection.MethodFrequency, "seleção do vocabulário do Naive Bayes e do MLP: "+strings.Join(selection.Methods, ", "))
+	features := flag.Int("features", 0, "termos mantidos no vocabulário do Naive Bayes e do MLP (0 = padrão: todos no NB, 1000 no MLP)")
+	minDF := flag.Int("min-df", 1, "documentos mínimos com um termo para entrar no vocabulário")
+	maxDF := flag.Float64("max-df", 1, "fração máxima dos documentos com um termo (1 = sem limite)")
+	hashing := flag.Int("hashing", 0, "dimensão do espaço de hashing do Naive Bayes e do MLP, que substitui o vocabulário (0 = vocabulário)")
+	ngrams := flag.Int("ngrams", 1, "maior n-grama de palavras no espaço de hashing")
+	hashSigned := flag.Bool("hash-signed", true, "sinal ±1 por termo no hashing do MLP, para que colisões tendam a se cancelar")
+	flag.IntVar(&augmentOptions.Copies, "augment", 0, "cópias aumentadas de cada documento de treinamento (0 = sem aumento de dados)")
+	flag.Float64Var(&augmentOptions.Strength, "augment-strength", augmentOptions.Strength, "fração das palavras removidas, trocadas ou substituídas por sinônimos em cada cópia")
+	augmentOps := flag.String("augment-ops", "", "operações de aumento separadas por vírgula: "+strings.Join(augment.Operations, ", ")+" (padrão: delete, swap e shuffle, mais synonym com --thesaurus)")
+	thesaurusPath := flag.String("thesaurus", "", "dicionário de sinônimos para o aumento de dados: um grupo \"palavra: sinônimo, sinônimo\" por linha")
+	stylometryFlag := flag.Bool("stylometry", false, "acrescenta características de estilo (pontuação, caixa alta, pronomes...) à entrada do MLP e dos modelos lineares")
+	vectorsPath := flag.String("embeddings", "", "arquivo de vetores pré-treinados (texto word2vec/GloVe) para --mlp-input embedding")
+	abstainCost := flag.Float64("abstain-cost", 0, "custo de um veredito inconclusivo, obrigatório (> 0) com --cost; abstém-se quando o custo esperado for maior")
+	flag.BoolVar(&groupDuplicates, "group-duplicates", false, "põe documentos quase-duplicados no mesmo fold da cross-validation")
+	flag.Float64Var(&duplicateOptions.Threshold, "dup-threshold", duplicateOptions.Threshold, "similaridade de Jaccard mínima (0-1) entre quase-duplicados")
+	flag.StringVar(&retrievalOptions.Scoring, "retrieval", retrievalOptions.Scoring, "pontuação das notícias semelhantes: "+strings.Join(retrieval.Scorings, " ou "))
+	flag.IntVar(&similarCount, "similar", similarCount, "notícias semelhantes do corpus exibidas por classe na classificação (0 = nenhuma)")
+	flag.Usage = printUsage
+	flag.Parse()
+	crawler.Configure(*crawlerOptions)
+	configurePolicy(*minConfidence, thresholds, costs, *abstainCost)
+	overrides := tuning.Params{}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "penalty":
+			overrides["penalty"] = *penalty
+		case "lambda":
+			overrides["lambda"] = strconv.FormatFloat(*lambda, 'g', -1, 64)
+		case "mlp-input":
+			overrides["input"] = *mlpInput
+		case "embedding-method":
+			overrides["embedding"] = *embeddingMethod
+		case "embedding-dim":
+			overrides["dim"] = strconv.Itoa(*embeddingDim)
+		case "embedding-pooling":
+			overrides["pooling"] = *embeddingPooling
+		case "embeddings":
+			overrides["vectors"] = *vectorsPath
+		case "stylometry":
+			overrides["stylometry"] = strconv.FormatBool(*stylometryFlag)
+		case "selection":
+			overrides["selection"] = *selectionMethod
+		case "features":
+			if *features > 0 {
+				overrides["features"] = strconv.Itoa(*features)
+				overrides["vocab"] = strconv.Itoa(*features)
+			}
+		case "min-df":
+			overrides["min_df"] = strconv.Itoa(*minDF)
+		case "max-df":
+			overrides["max_df"] = strconv.FormatFloat(*maxDF, 'g', -1, 64)
+		case "hashing":
+			overrides["hashing"] = strconv.Itoa(*hashing)
+		case "ngrams":
+			overrides["ngrams"] = strconv.Itoa(*ngrams)
+		case "hash-signed":
+			overrides["signed"] = strconv.FormatBool(*hashSigned)
+		}
+	})
+	configureHyperparameters(*configPath, overrides)
+	parsedWeights, err := decision.ParseAssignments(weights)
+	if err != nil {
+		log.Fatalf("Erro em --ensemble-weight: %v", err)
+	}
+	for member, weight := range parsedWeights {
+		known := false
+		for _, key := range ensembleWeightKeys {
+			known = known || member == key
+		}
+		if !known {
+			log.Fatalf("Erro em --ensemble-weight: membro desconhecido %q (use %s)", member, strings.Join(ensembleWeightKeys, ", "))
+		}
+		if weight <= 0 {
+			log.Fatalf("Erro em --ensemble-weight: o peso de %s deve ser maior que zero (omita o membro para aprender o peso)", member)
+		}
+	}
+	ensembleWeights = parsedWeights
+	if err := ensemble.ValidateMethod(ensembleMethod); err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+	if _, err := calibration.New(calibrationMethod); err != nil {
+		log.Fatalf("Erro: %v", err)
+	}
+	if _, err := neardup.NewIndex(duplicateOptions); err != nil {
+		log.Fatalf("Erro em --dup-threshold: %v", err)
+	}
+	if _, err := retrieval.NewIndex(nil, retrievalOptions); err != nil {
+		log.Fatalf("Erro em --retrieval: %v", err)
+	}
+	if *thesaurusPath != "" {
+		if thesaurus, err = augment.LoadThesaurus(*thesaurusPath); err != nil {
+			log.Fatalf("Erro em --thesaurus: %v", err)
+		}
+		augmentOptions.Operations = append(augmentOptions.Operations, augment.OpSynonym)
+	}
+	if *augmentOps != "" {
+		augmentOptions.Operations = nil
+		for _, op := range strings.Split(*augmentOps, ",") {
+			augmentOptions.Operations = append(augmentOptions.Operations, strings.TrimSpace(op))
+		}
+	}
+	if _, err := augment.New(augmentOptions, thesaurus); err != nil {
+		log.Fatalf("Erro no aumento de dados: %v", err)
+	}
+	if *sourceList != "" {
+		if reputationList, err = reputation.LoadList(*sourceList); err != nil {
+			log.Fatalf("Erro em --source-list: %v", err)
+		}
+	}
+
+	args := flag.Args()
+	if len(args) < 1 {
+		printUsage()
+		return
+	}
+
+	if args[0] == "cache-import" {
+		importCache(args[1:])
+		return
+	}
+
+	if args[0] == "crawl" {
+		runCrawl(args[1:])
+		return
+	}
+
+	// Carregar dataset (os registros do FakeTrue.Br, quando houver, servem ao stats)
+	docs, records, err := dataset.LoadWithRecords(datasetSource, datasetFormat)
+	if err != nil {
+		log.Fatalf("Falha ao carregar o dataset: %v", err)
+	}
+	if groupDuplicates {
+		grouped, merged, err := neardup.GroupDocuments(docs, duplicateOptions)
+		if err != nil {
+			log.Fatalf("Erro ao agrupar quase-duplicados: %v", err)
+		}
+		docs = grouped
+		fmt.Printf("Quase-duplicados agrupados: %d grupos fundidos (Jaccard ≥ %.2f)\n", merged, duplicateOptions.Threshold)
+	}
+
+	if args[0] == "mlp" {
+		if len(args) < 2 {
+			fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para classificação com MLP")
+			fmt.Println("Uso: go run cmd/classifier/main.go mlp <fonte>")
+			return
+		}
+		classifyNews(args[1], "MLP", docs)
+
+	} else if args[0] == "nb" {
+		if len(args) < 2 {
+			fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para classificação com Naive Bayes")
+			fmt.Println("Uso: go run cmd/classifier/main.go nb <fonte>")
+			return
+		}
+		classifyNews(args[1], "Naive Bayes", docs)
+
+	} else if args[0] == "logreg" || args[0] == "svm" {
+		if len(args) < 2 {
+			fmt.Printf("Erro: URL, arquivo ou - (stdin) necessário para classificação com %s\n", algorithmName(args[0]))
+			fmt.Printf("Uso: go run cmd/classifier/main.go [--penalty l1|l2] [--lambda 0.0001] %s <fonte>\n", args[0])
+			return
+		}
+		classifyNews(args[1], algorithmName(args[0]), docs)
+
+	} else if args[0] == "knn" || args[0] == "centroid" {
+		if len(args) < 2 {
+			fmt.Printf("Erro: URL, arquivo ou - (stdin) necessário para classificação com %s\n", algorithmName(args[0]))
+			fmt.Printf("Uso: go run cmd/classifier/main.go [--config config.json] %s <fonte>\n", args[0])
+			return
+		}
+		classifyNews(args[1], algorithmName(args[0]), docs)
+
+	} else if args[0] == "ensemble" {
+		if len(args) < 2 {
+			fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para classificação com o ensemble")
+			fmt.Println("Uso: go run cmd/classifier/main.go [--ensemble soft|weighted|stacking] ensemble <fonte>")
+			return
+		}
+		classifyNews(args[1], "Ensemble", docs)
+
+	} else if args[0] == "evaluate" {
+		runEvaluate(args[1:], docs)
+
+	} else if args[0] == "convert" {
+		runConvert(args[1:], docs)
+
+	} else if args[0] == "similar" {
+		runSimilar(args[1:], docs)
+
+	} else if args[0] == "vocab" {
+		runVocab(args[1:], docs)
+
+	} else if args[0] == "stats" {
+		runStats(args[1:], docs, records)
+
+	} else if args[0] == "topics" {
+		runTopics(args[1:], docs)
+
+	} else if args[0] == "style" {
+		runStyle(args[1:], docs)
+
+	} else if args[0] == "embeddings" {
+		runEmbeddings(args[1:], docs)
+
+	} else if args[0] == "dedup" {
+		runDedup(args[1:], docs)
+
+	} else if args[0] == "tune" {
+		runTune(args[1:], docs)
+
+	} else if args[0] == "explain" {
+		runExplain(args[1:], docs)
+
+	} else if args[0] == "segment" {
+		runSegment(args[1:], docs)
 
-	} else if os.Args[1] == "fast" {
-		if len(os.Args) < 3 {
-			fmt.Println("Erro: URL necessária para comparação rápida")
-			fmt.Println("Uso: go run cmd/classifier/main.go fast <url>")
+	} else if args[0] == "fast" {
+		if len(args) < 2 {
+			fmt.Println("Erro: URL, arquivo ou - (stdin) necessário para comparação rápida")
+			fmt.Println("Uso: go run cmd/classifier/main.go fast <fonte>")
 			return
 		}
-		compareAlgorithmsFast(os.Args[2], records)
+		compareAlgorithmsFast(args[1], docs)
 
 	} else {
-		// Comportamento padrão: comparar MLP e NB na URL fornecida
-		compareAlgorithms(os.Args[1], records)
+		// Comportamento padrão: comparar MLP e NB na fonte fornecida
+		compareAlgorithms(args[0], docs)
 	}
 }
@@ -0,0 +1,225 @@
+package augment
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/segment"
+)
+
+// Operações de aumento de dados
+const (
+	OpDelete  = "delete"  // remove palavras ao acaso
+	OpSwap    = "swap"    // troca pares de palavras de posição
+	OpSynonym = "synonym" // substitui palavras por sinônimos do dicionário
+	OpShuffle = "shuffle" // embaralha a ordem das sentenças
+)
+
+// Operations são as operações suportadas
+var Operations = []string{OpDelete, OpSwap, OpSynonym, OpShuffle}
+
+// MetadataKey é a chave de Metadata com a operação que gerou um documento aumentado
+const MetadataKey = "augmented"
+
+// Options configura o aumento de dados
+type Options struct {
+	Copies     int      // textos aumentados por documento de treinamento (0 = desligado)
+	Strength   float64  // fração das palavras alteradas por deleção, troca ou sinônimo (0-1]
+	Operations []string // operações usadas, alternadas entre as cópias
+	Seed       int64
+}
+
+// DefaultOptions retorna as opções padrão: desligado, 10% das palavras e as
+// operações que não precisam de dicionário de sinônimos
+func DefaultOptions() Options {
+	return Options{
+		Strength:   0.1,
+		Operations: []string{OpDelete, OpSwap, OpShuffle},
+		Seed:       1,
+	}
+}
+
+// Validate verifica as opções
+func (o Options) Validate() error {
+	if o.Copies < 0 {
+		return fmt.Errorf("o número de cópias deve ser ≥ 0")
+	}
+	if o.Strength <= 0 || o.Strength > 1 {
+		return fmt.Errorf("a intensidade do aumento deve estar em (0, 1]")
+	}
+	if len(o.Operations) == 0 {
+		return fmt.Errorf("nenhuma operação de aumento (use %s)", strings.Join(Operations, ", "))
+	}
+	for _, op := range o.Operations {
+		known := false
+		for _, name := range Operations {
+			known = known || op == name
+		}
+		if !known {
+			return fmt.Errorf("operação de aumento desconhecida: %s (use %s)", op, strings.Join(Operations, ", "))
+		}
+	}
+	return nil
+}
+
+// Augmenter gera textos aumentados com um gerador aleatório próprio
+type Augmenter struct {
+	Options
+	Thesaurus Thesaurus
+	rng       *rand.Rand
+}
+
+// New cria um gerador de textos aumentados; a operação synonym exige um dicionário
+func New(opts Options, thesaurus Thesaurus) (*Augmenter, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	for _, op := range opts.Operations {
+		if op == OpSynonym && len(thesaurus) == 0 {
+			return nil, fmt.Errorf("a operação synonym exige um dicionário de sinônimos")
+		}
+	}
+	return &Augmenter{
+		Options:   opts,
+		Thesaurus: thesaurus,
+		rng:       rand.New(rand.NewSource(opts.Seed)),
+	}, nil
+}
+
+// Documents retorna os documentos originais seguidos de Copies cópias aumentadas
+// de cada um, com as operações alternadas entre as cópias. Cópias idênticas ao
+// original (ex.: embaralhar um texto de uma sentença) são descartadas.
+func (a *Augmenter) Documents(docs []models.Document) []models.Document {
+	augmented := append([]models.Document(nil), docs...)
+	for i, doc := range docs {
+		for k := 0; k < a.Copies; k++ {
+			op := a.Operations[(i*a.Copies+k)%len(a.Operations)]
+			text := a.Text(doc.Text, op)
+			if text == "" || text == doc.Text {
+				continue
+			}
+
+			extra := doc
+			extra.ID = fmt.Sprintf("%s~%s%d", doc.ID, op, k+1)
+			extra.Text = text
+			extra.Metadata = map[string]string{MetadataKey: op}
+			for key, value := range doc.Metadata {
+				extra.Metadata[key] = value
+			}
+			augmented = append(augmented, extra)
+		}
+	}
+	return augmented
+}
+
+// Text aplica a operação ao texto
+func (a *Augmenter) Text(text string, op string) string {
+	switch op {
+	case OpDelete:
+		return a.delete(text)
+	case OpSwap:
+		return a.swap(text)
+	case OpSynonym:
+		return a.synonyms(text)
+	case OpShuffle:
+		return a.shuffle(text)
+	}
+	return text
+}
+
+// changes retorna quantas de n palavras uma operação altera: Strength·n, pelo menos uma
+func (a *Augmenter) changes(n int) int {
+	return int(math.Max(1, math.Round(a.Strength*float64(n))))
+}
+
+// delete remove cada palavra com probabilidade Strength, mantendo ao menos uma
+func (a *Augmenter) delete(text string) string {
+	words := strings.Fields(text)
+	if len(words) < 2 {
+		return text
+	}
+	var kept []string
+	for _, word := range words {
+		if a.rng.Float64() >= a.Strength {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		kept = []string{words[a.rng.Intn(len(words))]}
+	}
+	return strings.Join(kept, " ")
+}
+
+// swap troca Strength·n pares de palavras de posição
+func (a *Augmenter) swap(text string) string {
+	words := strings.Fields(text)
+	if len(words) < 2 {
+		return text
+	}
+	for k := a.changes(len(words)); k > 0; k-- {
+		i, j := a.rng.Intn(len(words)), a.rng.Intn(len(words))
+		words[i], words[j] = words[j], words[i]
+	}
+	return strings.Join(words, " ")
+}
+
+// synonyms substitui até Strength·n palavras com sinônimo no dicionário por um
+// sinônimo sorteado, preservando a pontuação ao redor e a inicial maiúscula
+func (a *Augmenter) synonyms(text string) string {
+	words := strings.Fields(text)
+	var candidates []int
+	for i, word := range words {
+		if len(a.Thesaurus.Synonyms(word)) > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return text
+	}
+
+	a.rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	n := a.changes(len(words))
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	for _, i := range candidates[:n] {
+		word := words[i]
+		synonyms := a.Thesaurus.Synonyms(word)
+		synonym := synonyms[a.rng.Intn(len(synonyms))]
+
+		// Preservar pontuação ("governo," → "executivo,") e inicial maiúscula
+		start := strings.IndexFunc(word, func(r rune) bool { return !unicode.IsPunct(r) })
+		end := strings.LastIndexFunc(word, func(r rune) bool { return !unicode.IsPunct(r) })
+		_, size := utf8.DecodeRuneInString(word[end:])
+		core := word[start : end+size]
+		if first, _ := utf8.DecodeRuneInString(core); unicode.IsUpper(first) {
+			r, size := utf8.DecodeRuneInString(synonym)
+			synonym = string(unicode.ToUpper(r)) + synonym[size:]
+		}
+		words[i] = word[:start] + synonym + word[end+size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// shuffle embaralha a ordem das sentenças do texto
+func (a *Augmenter) shuffle(text string) string {
+	segments := segment.Split(text, segment.LevelSentence)
+	if len(segments) < 2 {
+		return text
+	}
+	sentences := make([]string, len(segments))
+	for i, s := range segments {
+		sentences[i] = s.Text
+	}
+	a.rng.Shuffle(len(sentences), func(i, j int) {
+		sentences[i], sentences[j] = sentences[j], sentences[i]
+	})
+	return strings.Join(sentences, " ")
+}
@@ -0,0 +1,52 @@
+package augment
+
+import (
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Classifier treina o classificador base com os documentos de treinamento mais
+// as suas cópias aumentadas; a predição não muda (implementa models.Classifier).
+// Como o aumento acontece em Train, na cross-validation só os folds de treinamento
+// são aumentados e os documentos de teste continuam sendo os originais.
+type Classifier struct {
+	Base      models.Classifier
+	Options   Options
+	Thesaurus Thesaurus
+}
+
+// NewClassifier envolve o classificador base com o aumento de dados
+func NewClassifier(base models.Classifier, opts Options, thesaurus Thesaurus) *Classifier {
+	return &Classifier{Base: base, Options: opts, Thesaurus: thesaurus}
+}
+
+// Factory envolve cada classificador criado pela fábrica com o aumento de dados
+func Factory(factory models.Factory, opts Options, thesaurus Thesaurus) models.Factory {
+	return func() models.Classifier {
+		return NewClassifier(factory(), opts, thesaurus)
+	}
+}
+
+// Train aumenta os documentos de treinamento e treina o classificador base. O
+// gerador aleatório é recriado com a semente a cada treinamento, então o mesmo
+// fold recebe sempre as mesmas cópias.
+func (c *Classifier) Train(docs []models.Document) {
+	augmenter, err := New(c.Options, c.Thesaurus)
+	if err != nil {
+		// Opções inválidas (rejeitadas na configuração): treinar sem aumento
+		c.Base.Train(docs)
+		return
+	}
+	augmented := augmenter.Documents(docs)
+	models.Logf("Aumento de dados: %d documentos de treinamento + %d cópias aumentadas\n", len(docs), len(augmented)-len(docs))
+	c.Base.Train(augmented)
+}
+
+// Predict classifica o texto com o classificador base
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	return c.Base.Predict(text)
+}
+
+// Probabilities retorna as probabilidades do classificador base (implementa models.ProbabilityModel)
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	return models.Probabilities(c.Base, text)
+}
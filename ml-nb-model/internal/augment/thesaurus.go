@@ -0,0 +1,83 @@
+package augment
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Thesaurus associa cada palavra (em minúsculas) aos seus sinônimos
+type Thesaurus map[string][]string
+
+// LoadThesaurus lê um dicionário de sinônimos: um grupo de sinônimos por linha,
+// separados por vírgula ou ponto e vírgula, opcionalmente com a palavra principal
+// antes de dois-pontos ("casa: lar, moradia"). Todas as palavras de um grupo são
+// sinônimas entre si; linhas vazias e iniciadas por # são ignoradas.
+func LoadThesaurus(path string) (Thesaurus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	groups := make(map[string]map[string]bool)
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var words []string
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == ':' }) {
+			if word := normalize(field); word != "" {
+				words = append(words, word)
+			}
+		}
+		if len(words) < 2 {
+			return nil, fmt.Errorf("%s:%d: use \"palavra: sinônimo, sinônimo\" ou \"palavra, sinônimo, ...\"", path, line)
+		}
+
+		for _, word := range words {
+			if groups[word] == nil {
+				groups[word] = make(map[string]bool)
+			}
+			for _, synonym := range words {
+				if synonym != word {
+					groups[word][synonym] = true
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	thesaurus := make(Thesaurus, len(groups))
+	for word, synonyms := range groups {
+		for synonym := range synonyms {
+			thesaurus[word] = append(thesaurus[word], synonym)
+		}
+		sort.Strings(thesaurus[word])
+	}
+	return thesaurus, nil
+}
+
+// Synonyms retorna os sinônimos da palavra (sem diferenciar maiúsculas)
+func (t Thesaurus) Synonyms(word string) []string {
+	return t[normalize(word)]
+}
+
+// normalize deixa a palavra em minúsculas, sem pontuação nas pontas e com
+// espaços simples (sinônimos podem ter mais de uma palavra)
+func normalize(word string) string {
+	word = strings.TrimFunc(word, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
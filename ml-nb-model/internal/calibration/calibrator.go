@@ -0,0 +1,94 @@
+package calibration
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Métodos de calibração suportados
+const (
+	MethodNone        = "none"
+	MethodPlatt       = "platt"
+	MethodIsotonic    = "isotonic"
+	MethodTemperature = "temperature"
+)
+
+// Methods lista os métodos de calibração suportados
+var Methods = []string{MethodNone, MethodPlatt, MethodIsotonic, MethodTemperature}
+
+// epsilon evita log(0) ao transformar probabilidades
+const epsilon = 1e-6
+
+// Calibrator mapeia as probabilidades brutas de um classificador (0-100) para
+// probabilidades calibradas (0-100, somando 100)
+type Calibrator interface {
+	// Fit ajusta o calibrador a partir de probabilidades brutas e rótulos verdadeiros
+	Fit(probs []map[string]float64, labels []string)
+	// Calibrate retorna as probabilidades calibradas
+	Calibrate(probs map[string]float64) map[string]float64
+}
+
+// New cria um calibrador para o método informado (nil para MethodNone)
+func New(method string) (Calibrator, error) {
+	switch method {
+	case MethodNone, "":
+		return nil, nil
+	case MethodPlatt:
+		return &Platt{}, nil
+	case MethodIsotonic:
+		return &Isotonic{}, nil
+	case MethodTemperature:
+		return &Temperature{}, nil
+	}
+	return nil, fmt.Errorf("método de calibração desconhecido: %s (use none, platt, isotonic ou temperature)", method)
+}
+
+// classesOf retorna as classes presentes nas probabilidades e nos rótulos, em ordem alfabética
+func classesOf(probs []map[string]float64, labels []string) []string {
+	seen := make(map[string]bool)
+	for _, p := range probs {
+		for label := range p {
+			seen[label] = true
+		}
+	}
+	for _, label := range labels {
+		seen[label] = true
+	}
+
+	classes := make([]string, 0, len(seen))
+	for label := range seen {
+		classes = append(classes, label)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// clamp limita uma probabilidade (0-1) ao intervalo [epsilon, 1-epsilon]
+func clamp(p float64) float64 {
+	return math.Min(math.Max(p, epsilon), 1-epsilon)
+}
+
+// logit retorna log(p / (1-p)) para uma probabilidade na escala 0-100
+func logit(percent float64) float64 {
+	p := clamp(percent / 100)
+	return math.Log(p / (1 - p))
+}
+
+// normalize reescala as pontuações para somarem 100 (uniforme se todas forem nulas)
+func normalize(scores map[string]float64) map[string]float64 {
+	sum := 0.0
+	for _, v := range scores {
+		sum += v
+	}
+
+	result := make(map[string]float64, len(scores))
+	for label, v := range scores {
+		if sum > 0 {
+			result[label] = v / sum * 100
+		} else {
+			result[label] = 100 / float64(len(scores))
+		}
+	}
+	return result
+}
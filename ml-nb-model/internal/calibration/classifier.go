@@ -0,0 +1,124 @@
+package calibration
+
+import (
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// DefaultFolds é o número de folds usado para obter as predições de ajuste do calibrador
+const DefaultFolds = 5
+
+// Classifier envolve um classificador e calibra suas probabilidades
+// (implementa models.Classifier)
+type Classifier struct {
+	Factory    models.Factory
+	Calibrator Calibrator
+	Folds      int
+	Base       models.Classifier
+}
+
+// NewClassifier cria um classificador calibrado a partir da fábrica do modelo base
+func NewClassifier(factory models.Factory, calibrator Calibrator) *Classifier {
+	return &Classifier{
+		Factory:    factory,
+		Calibrator: calibrator,
+		Folds:      DefaultFolds,
+	}
+}
+
+// Train ajusta o calibrador com as predições out-of-fold do modelo base
+// (nenhum documento é pontuado por um modelo que o viu no treino) e depois
+// treina o modelo base com todos os documentos
+func (c *Classifier) Train(docs []models.Document) {
+	models.Logf("Ajustando calibração com %d folds...\n", c.Folds)
+	predictions := evaluation.CrossValidate(docs, c.Factory, c.Folds, nil)
+	Fit(c.Calibrator, predictions)
+
+	c.Base = c.Factory()
+	c.Base.Train(docs)
+}
+
+// Predict classifica o texto com o modelo base e calibra as probabilidades.
+// As probabilidades originais ficam em RawProbabilities.
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	result := c.Base.Predict(text)
+	return Apply(c.Calibrator, result)
+}
+
+// Probabilities retorna as probabilidades calibradas, sem as contribuições dos
+// tokens do modelo base (implementa models.ProbabilityModel)
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	return c.Calibrator.Calibrate(models.Probabilities(c.Base, text))
+}
+
+// Fit ajusta o calibrador a partir de predições com rótulo conhecido
+func Fit(calibrator Calibrator, predictions []evaluation.Prediction) {
+	probs := make([]map[string]float64, len(predictions))
+	labels := make([]string, len(predictions))
+	for i, p := range predictions {
+		probs[i] = p.Result.Probabilities
+		labels[i] = p.Actual
+	}
+	calibrator.Fit(probs, labels)
+}
+
+// Apply substitui as probabilidades, o rótulo e a confiança do resultado pelos
+// valores calibrados, preservando os originais em RawProbabilities
+func Apply(calibrator Calibrator, result models.ClassificationResult) models.ClassificationResult {
+	raw := result.Probabilities
+	result.RawProbabilities = raw
+	result.Probabilities = calibrator.Calibrate(raw)
+
+	labels := make([]string, 0, len(result.Probabilities))
+	for label := range result.Probabilities {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	result.Label = ""
+	for _, label := range labels {
+		if prob := result.Probabilities[label]; result.Label == "" || prob > result.Confidence {
+			result.Label = label
+			result.Confidence = prob
+		}
+	}
+	return result
+}
+
+// CrossCalibrate calibra predições out-of-fold sem vazamento: as predições de
+// cada fold são calibradas por um calibrador ajustado apenas nos demais folds
+func CrossCalibrate(method string, predictions []evaluation.Prediction) ([]evaluation.Prediction, error) {
+	byFold := make(map[int][]evaluation.Prediction)
+	for _, p := range predictions {
+		byFold[p.Fold] = append(byFold[p.Fold], p)
+	}
+
+	calibrated := make([]evaluation.Prediction, len(predictions))
+	for fold := range byFold {
+		calibrator, err := New(method)
+		if err != nil {
+			return nil, err
+		}
+		if calibrator == nil {
+			return predictions, nil
+		}
+
+		var train []evaluation.Prediction
+		for other, preds := range byFold {
+			if other != fold {
+				train = append(train, preds...)
+			}
+		}
+		Fit(calibrator, train)
+
+		for i, p := range predictions {
+			if p.Fold == fold {
+				p.Result = Apply(calibrator, p.Result)
+				calibrated[i] = p
+			}
+		}
+	}
+	return calibrated, nil
+}
@@ -0,0 +1,105 @@
+package calibration
+
+import "sort"
+
+// Isotonic calibra cada classe (um contra todos) com uma função monotônica
+// não decrescente ajustada por regressão isotônica (pool adjacent violators).
+// As probabilidades das classes são renormalizadas para somarem 100.
+type Isotonic struct {
+	Curves map[string]*isotonicCurve
+}
+
+// isotonicCurve guarda os pontos da função ajustada (x na escala 0-1)
+type isotonicCurve struct {
+	X []float64
+	Y []float64
+}
+
+// Fit ajusta uma curva isotônica por classe
+func (iso *Isotonic) Fit(probs []map[string]float64, labels []string) {
+	iso.Curves = make(map[string]*isotonicCurve)
+
+	for _, class := range classesOf(probs, labels) {
+		x := make([]float64, len(probs))
+		y := make([]float64, len(probs))
+		for i := range probs {
+			x[i] = probs[i][class] / 100
+			if labels[i] == class {
+				y[i] = 1
+			}
+		}
+		iso.Curves[class] = fitIsotonic(x, y)
+	}
+}
+
+// Calibrate interpola as curvas ajustadas e renormaliza
+func (iso *Isotonic) Calibrate(probs map[string]float64) map[string]float64 {
+	scores := make(map[string]float64, len(probs))
+	for class, prob := range probs {
+		curve, fitted := iso.Curves[class]
+		if !fitted || len(curve.X) == 0 {
+			scores[class] = prob / 100
+			continue
+		}
+		scores[class] = curve.at(prob / 100)
+	}
+	return normalize(scores)
+}
+
+// fitIsotonic executa o algoritmo pool adjacent violators sobre os pares (x, y)
+// ordenados por x; cada bloco resultante vira um ponto (x médio, y médio)
+func fitIsotonic(x, y []float64) *isotonicCurve {
+	order := make([]int, len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return x[order[a]] < x[order[b]] })
+
+	type block struct {
+		sumX, sumY, count float64
+	}
+	var blocks []block
+	for _, i := range order {
+		blocks = append(blocks, block{sumX: x[i], sumY: y[i], count: 1})
+		// Unir blocos enquanto a média do anterior for maior que a do último
+		for len(blocks) > 1 {
+			last := blocks[len(blocks)-1]
+			prev := blocks[len(blocks)-2]
+			if prev.sumY/prev.count <= last.sumY/last.count {
+				break
+			}
+			blocks = blocks[:len(blocks)-2]
+			blocks = append(blocks, block{
+				sumX:  prev.sumX + last.sumX,
+				sumY:  prev.sumY + last.sumY,
+				count: prev.count + last.count,
+			})
+		}
+	}
+
+	curve := &isotonicCurve{}
+	for _, b := range blocks {
+		curve.X = append(curve.X, b.sumX/b.count)
+		curve.Y = append(curve.Y, b.sumY/b.count)
+	}
+	return curve
+}
+
+// at avalia a curva por interpolação linear, constante fora do intervalo ajustado
+func (c *isotonicCurve) at(x float64) float64 {
+	n := len(c.X)
+	if x <= c.X[0] {
+		return c.Y[0]
+	}
+	if x >= c.X[n-1] {
+		return c.Y[n-1]
+	}
+
+	i := sort.SearchFloat64s(c.X, x)
+	x0, x1 := c.X[i-1], c.X[i]
+	y0, y1 := c.Y[i-1], c.Y[i]
+	if x1 == x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
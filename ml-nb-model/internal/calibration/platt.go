@@ -0,0 +1,120 @@
+package calibration
+
+import "math"
+
+// Platt calibra cada classe (um contra todos) com uma sigmoide sobre o logit da
+// probabilidade bruta: P(c) = 1 / (1 + exp(A·s + B)), com s = logit(p_c).
+// As probabilidades das classes são renormalizadas para somarem 100.
+type Platt struct {
+	A map[string]float64
+	B map[string]float64
+}
+
+// Fit ajusta A e B de cada classe por máxima verossimilhança (método de Newton),
+// usando os alvos suavizados propostos por Platt para reduzir o sobreajuste
+func (p *Platt) Fit(probs []map[string]float64, labels []string) {
+	p.A = make(map[string]float64)
+	p.B = make(map[string]float64)
+
+	for _, class := range classesOf(probs, labels) {
+		scores := make([]float64, len(probs))
+		positive := make([]bool, len(probs))
+		for i := range probs {
+			scores[i] = logit(probs[i][class])
+			positive[i] = labels[i] == class
+		}
+		p.A[class], p.B[class] = fitSigmoid(scores, positive)
+	}
+}
+
+// Calibrate aplica as sigmoides ajustadas e renormaliza
+func (p *Platt) Calibrate(probs map[string]float64) map[string]float64 {
+	scores := make(map[string]float64, len(probs))
+	for class, prob := range probs {
+		a, fitted := p.A[class]
+		if !fitted {
+			scores[class] = prob / 100
+			continue
+		}
+		scores[class] = 1 / (1 + math.Exp(a*logit(prob)+p.B[class]))
+	}
+	return normalize(scores)
+}
+
+// fitSigmoid encontra A e B que minimizam a entropia cruzada de
+// 1 / (1 + exp(A·s + B)) em relação aos alvos suavizados
+func fitSigmoid(scores []float64, positive []bool) (float64, float64) {
+	var nPos, nNeg float64
+	for _, pos := range positive {
+		if pos {
+			nPos++
+		} else {
+			nNeg++
+		}
+	}
+
+	hiTarget := (nPos + 1) / (nPos + 2)
+	loTarget := 1 / (nNeg + 2)
+	targets := make([]float64, len(scores))
+	for i, pos := range positive {
+		if pos {
+			targets[i] = hiTarget
+		} else {
+			targets[i] = loTarget
+		}
+	}
+
+	// Ponto inicial: identidade sobre o logit (A = -1) com o prior como viés
+	a, b := -1.0, 0.0
+	loss := sigmoidLoss(scores, targets, a, b)
+
+	for iter := 0; iter < 100; iter++ {
+		// Gradiente e Hessiana (com pequena regularização para estabilidade)
+		h11, h22, h21 := 1e-12, 1e-12, 0.0
+		g1, g2 := 0.0, 0.0
+		for i, s := range scores {
+			prob := 1 / (1 + math.Exp(a*s+b))
+			d1 := targets[i] - prob
+			d2 := prob * (1 - prob)
+			h11 += s * s * d2
+			h22 += d2
+			h21 += s * d2
+			g1 += s * d1
+			g2 += d1
+		}
+		if math.Abs(g1) < 1e-7 && math.Abs(g2) < 1e-7 {
+			break
+		}
+
+		// Passo de Newton com busca linear por retrocesso
+		det := h11*h22 - h21*h21
+		da := -(h22*g1 - h21*g2) / det
+		db := -(-h21*g1 + h11*g2) / det
+		step := 1.0
+		for step > 1e-10 {
+			newLoss := sigmoidLoss(scores, targets, a+step*da, b+step*db)
+			if newLoss < loss+1e-4*step*(g1*da+g2*db) {
+				a, b, loss = a+step*da, b+step*db, newLoss
+				break
+			}
+			step /= 2
+		}
+		if step <= 1e-10 {
+			break
+		}
+	}
+
+	return a, b
+}
+
+// sigmoidLoss calcula a entropia cruzada da sigmoide 1 / (1 + exp(A·s + B))
+func sigmoidLoss(scores, targets []float64, a, b float64) float64 {
+	loss := 0.0
+	for i, s := range scores {
+		z := a*s + b
+		// log(1 + exp(z)) calculado de forma estável
+		softplus := math.Max(z, 0) + math.Log1p(math.Exp(-math.Abs(z)))
+		loss += targets[i]*softplus + (1-targets[i])*(softplus-z)
+	}
+	return loss
+}
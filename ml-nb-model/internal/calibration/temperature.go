@@ -0,0 +1,67 @@
+package calibration
+
+import "math"
+
+// Temperature calibra todas as classes com um único parâmetro T aplicado aos
+// log-probabilidades: P(c) = softmax(log p_c / T). T > 1 suaviza predições
+// excessivamente confiantes; a classe prevista nunca muda.
+type Temperature struct {
+	T float64
+}
+
+// Fit escolhe T minimizando a log-verossimilhança negativa por busca da seção
+// áurea sobre log T no intervalo [1/100, 100]
+func (t *Temperature) Fit(probs []map[string]float64, labels []string) {
+	nll := func(logT float64) float64 {
+		temp := math.Exp(logT)
+		loss := 0.0
+		for i := range probs {
+			calibrated := softmaxTemperature(probs[i], temp)
+			loss -= math.Log(clamp(calibrated[labels[i]] / 100))
+		}
+		return loss
+	}
+
+	lo, hi := math.Log(0.01), math.Log(100)
+	ratio := (math.Sqrt(5) - 1) / 2
+	x1 := hi - ratio*(hi-lo)
+	x2 := lo + ratio*(hi-lo)
+	f1, f2 := nll(x1), nll(x2)
+	for hi-lo > 1e-4 {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - ratio*(hi-lo)
+			f1 = nll(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + ratio*(hi-lo)
+			f2 = nll(x2)
+		}
+	}
+
+	t.T = math.Exp((lo + hi) / 2)
+}
+
+// Calibrate aplica a temperatura ajustada
+func (t *Temperature) Calibrate(probs map[string]float64) map[string]float64 {
+	if t.T <= 0 {
+		return normalize(probs)
+	}
+	return softmaxTemperature(probs, t.T)
+}
+
+// softmaxTemperature calcula softmax(log p / T) na escala 0-100
+func softmaxTemperature(probs map[string]float64, temp float64) map[string]float64 {
+	logits := make(map[string]float64, len(probs))
+	maxLogit := math.Inf(-1)
+	for class, prob := range probs {
+		logits[class] = math.Log(clamp(prob/100)) / temp
+		maxLogit = math.Max(maxLogit, logits[class])
+	}
+
+	scores := make(map[string]float64, len(probs))
+	for class, l := range logits {
+		scores[class] = math.Exp(l - maxLogit)
+	}
+	return normalize(scores)
+}
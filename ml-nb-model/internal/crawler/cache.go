@@ -0,0 +1,144 @@
+package crawler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotCached indica que a URL não está no cache (ou expirou) em modo offline
+var ErrNotCached = errors.New("URL não encontrada no cache")
+
+// CachedResponse representa uma resposta HTTP armazenada no cache
+type CachedResponse struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	BodyHash   string      `json:"body_hash"`
+	FetchedAt  time.Time   `json:"fetched_at"`
+	Body       []byte      `json:"-"`
+}
+
+// Cache armazena respostas HTTP em disco.
+// Os corpos são endereçados pelo conteúdo (SHA-256 do corpo, em blobs/),
+// e cada URL aponta para o seu corpo através de um registro em entries/.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewCache cria um cache no diretório informado
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// DefaultCacheDir retorna o diretório padrão do cache de respostas
+func DefaultCacheDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = ".cache"
+	}
+	return filepath.Join(base, "ml-nb-model", "http")
+}
+
+// hashHex retorna o SHA-256 em hexadecimal
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// entryPath retorna o caminho do registro de uma URL
+func (c *Cache) entryPath(url string) string {
+	return filepath.Join(c.Dir, "entries", hashHex([]byte(url))+".json")
+}
+
+// blobPath retorna o caminho do corpo com o hash informado; o booleano é falso
+// quando o hash não é um SHA-256 em hexadecimal (registro corrompido ou editado)
+func (c *Cache) blobPath(hash string) (string, bool) {
+	if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+		return "", false
+	}
+	return filepath.Join(c.Dir, "blobs", hash[:2], hash), true
+}
+
+// Get busca uma URL no cache. O booleano indica se o registro ainda está
+// dentro do TTL; registros expirados também são retornados para uso offline.
+func (c *Cache) Get(url string) (*CachedResponse, bool, error) {
+	data, err := os.ReadFile(c.entryPath(url))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, ErrNotCached
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Registro corrompido (ex.: gravação interrompida): descartar e tratar como
+	// ausente, para que a URL seja baixada de novo
+	var entry CachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		os.Remove(c.entryPath(url))
+		return nil, false, ErrNotCached
+	}
+
+	// Hash inválido: tratar como ausente, para que a URL seja baixada de novo
+	blob, ok := c.blobPath(entry.BodyHash)
+	if !ok {
+		return nil, false, ErrNotCached
+	}
+	body, err := os.ReadFile(blob)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, ErrNotCached
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	entry.Body = body
+
+	fresh := c.TTL <= 0 || time.Since(entry.FetchedAt) < c.TTL
+	return &entry, fresh, nil
+}
+
+// Put grava uma resposta no cache
+func (c *Cache) Put(entry *CachedResponse) error {
+	entry.BodyHash = hashHex(entry.Body)
+
+	blob, _ := c.blobPath(entry.BodyHash)
+	if _, err := os.Stat(blob); err != nil {
+		if err := writeFileAtomic(blob, entry.Body); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.entryPath(entry.URL), data)
+}
+
+// writeFileAtomic grava um arquivo via arquivo temporário + rename
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,116 @@
+package crawler
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Options configura o cache e o modo offline usados por Fetch
+type Options struct {
+	CacheDir string
+	TTL      time.Duration
+	NoCache  bool
+	Offline  bool
+}
+
+// DefaultOptions retorna a configuração padrão (cache ligado, TTL de 7 dias)
+func DefaultOptions() Options {
+	return Options{
+		CacheDir: DefaultCacheDir(),
+		TTL:      7 * 24 * time.Hour,
+	}
+}
+
+var options = DefaultOptions()
+
+// Configure define as opções globais do crawler
+func Configure(opts Options) {
+	options = opts
+}
+
+// RegisterFlags registra as flags de cache/offline em um FlagSet.
+// As opções retornadas devem ser passadas para Configure após o parse.
+func RegisterFlags(fs *flag.FlagSet) *Options {
+	opts := DefaultOptions()
+	fs.StringVar(&opts.CacheDir, "cache-dir", opts.CacheDir, "diretório do cache de respostas HTTP")
+	fs.DurationVar(&opts.TTL, "cache-ttl", opts.TTL, "validade das respostas em cache (0 = sem expiração)")
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "não ler nem gravar o cache de respostas")
+	fs.BoolVar(&opts.Offline, "offline", false, "usar apenas respostas em cache, sem acessar a rede")
+	return &opts
+}
+
+// cache retorna o cache configurado, ou nil se desativado
+func cache() *Cache {
+	if options.NoCache || options.CacheDir == "" {
+		return nil
+	}
+	return NewCache(options.CacheDir, options.TTL)
+}
+
+// Fetch obtém uma URL respeitando o cache e o modo offline
+func Fetch(url string) (*CachedResponse, error) {
+	c := cache()
+
+	if c != nil {
+		entry, fresh, err := c.Get(url)
+		if err == nil && (fresh || options.Offline) {
+			return entry, nil
+		}
+		if err != nil && !errors.Is(err, ErrNotCached) {
+			return nil, err
+		}
+	}
+
+	if options.Offline {
+		return nil, fmt.Errorf("modo offline: %w: %s", ErrNotCached, url)
+	}
+
+	entry, err := fetchRemote(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		if err := c.Put(entry); err != nil {
+			return nil, fmt.Errorf("falha ao gravar no cache: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// fetchRemote faz a requisição HTTP sem passar pelo cache
+func fetchRemote(url string) (*CachedResponse, error) {
+	// Criar cliente HTTP com timeout
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	// Fazer requisição
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("falha na requisição: status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CachedResponse{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		FetchedAt:  time.Now(),
+		Body:       body,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package crawler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// savedFromRegex reconhece o comentário "saved from url" gravado pelos navegadores
+var savedFromRegex = regexp.MustCompile(`<!--\s*saved from url=\(\d+\)(\S+?)\s*-->`)
+
+// DetectURL tenta descobrir a URL original de uma página HTML salva
+// (link canônico, og:url ou comentário "saved from url")
+func DetectURL(html []byte) string {
+	if match := savedFromRegex.FindSubmatch(html); match != nil {
+		return string(match[1])
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
+	if err != nil {
+		return ""
+	}
+
+	if href, ok := doc.Find(`link[rel="canonical"]`).Attr("href"); ok && strings.HasPrefix(href, "http") {
+		return href
+	}
+	if content, ok := doc.Find(`meta[property="og:url"]`).Attr("content"); ok && strings.HasPrefix(content, "http") {
+		return content
+	}
+
+	return ""
+}
+
+// ImportHTML grava um arquivo HTML salvo no cache como resposta da URL informada.
+// Se url for vazia, a URL é detectada a partir do próprio HTML.
+func ImportHTML(path string, url string) (string, error) {
+	c := cache()
+	if c == nil {
+		return "", errors.New("cache desativado")
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	if url == "" {
+		url = DetectURL(body)
+		if url == "" {
+			return "", fmt.Errorf("não foi possível detectar a URL de %s (use --url)", path)
+		}
+	}
+
+	entry := &CachedResponse{
+		URL:        url,
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Content-Type": {http.DetectContentType(body)}},
+		FetchedAt:  time.Now(),
+		Body:       body,
+	}
+
+	return url, c.Put(entry)
+}
@@ -0,0 +1,230 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// CrawlConfig configura a coleta de artigos a partir de páginas índice
+type CrawlConfig struct {
+	Seeds    []string         // páginas iniciais (ex.: listagem de um site de checagem)
+	Follow   []*regexp.Regexp // links a seguir sem extrair (índices, paginação)
+	Articles []*regexp.Regexp // links de artigos a extrair
+	MaxDepth int              // profundidade máxima a partir das sementes
+	MaxPages int              // número máximo de páginas baixadas
+	Workers  int              // tamanho do pool de workers
+	MinChars int              // tamanho mínimo do texto para aceitar um artigo
+	SameHost bool             // seguir apenas links do mesmo host da semente
+	Delay    time.Duration    // pausa de cada worker entre requisições
+}
+
+// Article representa um artigo extraído durante a coleta
+type Article struct {
+	URL   string
+	Title string
+	Text  string
+	Depth int
+}
+
+// crawlResult é o resultado do processamento de uma página
+type crawlResult struct {
+	article *Article
+	links   []string
+	err     error
+}
+
+// Crawl percorre os links a partir das sementes, em largura, e extrai os artigos encontrados.
+// Páginas que falham são reportadas em errs, sem interromper a coleta.
+func Crawl(cfg CrawlConfig) (articles []Article, errs []error) {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	hosts := make(map[string]bool)
+	visited := make(map[string]bool)
+	var level []string
+	for _, seed := range cfg.Seeds {
+		if u, err := url.Parse(seed); err == nil {
+			hosts[u.Host] = true
+		}
+		if !visited[seed] {
+			visited[seed] = true
+			level = append(level, seed)
+		}
+	}
+
+	fetched := 0
+	for depth := 0; depth <= cfg.MaxDepth && len(level) > 0; depth++ {
+		// Respeitar o orçamento de páginas
+		if cfg.MaxPages > 0 && fetched+len(level) > cfg.MaxPages {
+			level = level[:cfg.MaxPages-fetched]
+		}
+		fetched += len(level)
+
+		results := crawlLevel(cfg, level, depth)
+
+		var next []string
+		for i, result := range results {
+			if result.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", level[i], result.err))
+				continue
+			}
+			if result.article != nil {
+				articles = append(articles, *result.article)
+			}
+			for _, link := range result.links {
+				if visited[link] {
+					continue
+				}
+				if cfg.SameHost && !sameHost(hosts, link) {
+					continue
+				}
+				visited[link] = true
+				next = append(next, link)
+			}
+		}
+
+		if cfg.MaxPages > 0 && fetched >= cfg.MaxPages {
+			break
+		}
+		level = next
+	}
+
+	return articles, errs
+}
+
+// crawlLevel processa as páginas de um nível com um pool de workers,
+// preservando a ordem das páginas nos resultados
+func crawlLevel(cfg CrawlConfig, pages []string, depth int) []crawlResult {
+	results := make([]crawlResult, len(pages))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = crawlPage(cfg, pages[i], depth)
+				if cfg.Delay > 0 {
+					time.Sleep(cfg.Delay)
+				}
+			}
+		}()
+	}
+
+	for i := range pages {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// crawlPage baixa uma página, extrai o artigo (se for o caso) e os links a seguir
+func crawlPage(cfg CrawlConfig, pageURL string, depth int) crawlResult {
+	resp, err := Fetch(pageURL)
+	if err != nil {
+		return crawlResult{err: err}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body))
+	if err != nil {
+		return crawlResult{err: err}
+	}
+
+	var result crawlResult
+
+	// Coletar links antes de extrair o texto (ExtractText remove elementos)
+	if depth < cfg.MaxDepth {
+		base, _ := url.Parse(pageURL)
+		doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+			href, _ := s.Attr("href")
+			link := resolveLink(base, href)
+			if link != "" && shouldFollow(cfg, link) {
+				result.links = append(result.links, link)
+			}
+		})
+	}
+
+	// Sementes e páginas de índice não são artigos
+	isArticle := depth > 0 && (len(cfg.Articles) == 0 || matchesAny(cfg.Articles, pageURL))
+	if !isArticle {
+		return result
+	}
+
+	text, err := ExtractText(bytes.NewReader(resp.Body))
+	if err != nil {
+		return crawlResult{err: err}
+	}
+	if len(text) < cfg.MinChars {
+		return result
+	}
+
+	result.article = &Article{
+		URL:   pageURL,
+		Title: extractTitle(doc),
+		Text:  text,
+		Depth: depth,
+	}
+	return result
+}
+
+// extractTitle obtém o título do artigo (og:title, h1 ou <title>)
+func extractTitle(doc *goquery.Document) string {
+	if title, ok := doc.Find(`meta[property="og:title"]`).Attr("content"); ok && strings.TrimSpace(title) != "" {
+		return strings.TrimSpace(title)
+	}
+	if h1 := strings.TrimSpace(doc.Find("h1").First().Text()); h1 != "" {
+		return h1
+	}
+	return strings.TrimSpace(doc.Find("title").First().Text())
+}
+
+// shouldFollow verifica se um link casa com os padrões configurados.
+// Sem nenhum padrão, todos os links são seguidos.
+func shouldFollow(cfg CrawlConfig, link string) bool {
+	if len(cfg.Follow) == 0 && len(cfg.Articles) == 0 {
+		return true
+	}
+	return matchesAny(cfg.Follow, link) || matchesAny(cfg.Articles, link)
+}
+
+// resolveLink converte um href em URL absoluta sem fragmento
+func resolveLink(base *url.URL, href string) string {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || base == nil {
+		return ""
+	}
+
+	link := base.ResolveReference(ref)
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return ""
+	}
+	link.Fragment = ""
+	return link.String()
+}
+
+// sameHost verifica se o link pertence a um dos hosts das sementes
+func sameHost(hosts map[string]bool, link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && hosts[u.Host]
+}
+
+// matchesAny verifica se o texto casa com algum dos padrões
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
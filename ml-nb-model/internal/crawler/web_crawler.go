@@ -1,35 +1,29 @@
 package crawler
 
 import (
-	"fmt"
-	"net/http"
+	"bytes"
+	"io"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
 // CrawlNews extrai o conteúdo de uma URL de notícia
 func CrawlNews(url string) (string, error) {
-	// Criar cliente HTTP com timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	// Fazer requisição
-	resp, err := client.Get(url)
+	// Obter a página (usando o cache quando disponível)
+	resp, err := Fetch(url)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("falha na requisição: status code %d", resp.StatusCode)
-	}
+	return ExtractText(bytes.NewReader(resp.Body))
+}
 
+// ExtractText extrai o texto principal de um documento HTML
+func ExtractText(r io.Reader) (string, error) {
 	// Carregar documento HTML
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return "", err
 	}
@@ -68,18 +62,18 @@ func CrawlNews(url string) (string, error) {
 		})
 	}
 
-	// Juntar todo o conteúdo
-	fullText := strings.Join(textContent, " ")
-
-	// Limpar texto
-	fullText = strings.ReplaceAll(fullText, "\n", " ")
-	fullText = strings.ReplaceAll(fullText, "\t", " ")
-
-	// Remover espaços múltiplos
+	// Limpar cada parágrafo, removendo quebras de linha e espaços múltiplos
 	reg := regexp.MustCompile(`\s+`)
-	fullText = reg.ReplaceAllString(fullText, " ")
+	var paragraphs []string
+	for _, text := range textContent {
+		text = strings.TrimSpace(reg.ReplaceAllString(text, " "))
+		if text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
 
-	fullText = strings.TrimSpace(fullText)
+	// Juntar todo o conteúdo, mantendo uma linha em branco entre parágrafos
+	fullText := strings.Join(paragraphs, "\n\n")
 
 	return fullText, nil
 }
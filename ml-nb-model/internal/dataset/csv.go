@@ -0,0 +1,165 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/crawler"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// DefaultURL é o endereço do corpus FakeTrue.Br
+const DefaultURL = "https://raw.githubusercontent.com/jpchav98/FakeTrue.Br/refs/heads/main/FakeTrueBr_corpus.csv"
+
+// header é o cabeçalho gravado por WriteCSV (mesma ordem das colunas do FakeTrue.Br)
+var header = []string{"title_fake", "fake", "link_fake", "true", "link_true"}
+
+// LoadURL carrega o dataset de uma URL (usando o cache do crawler)
+func LoadURL(url string) ([]models.NewsRecord, error) {
+	resp, err := crawler.Fetch(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(resp.Body))
+}
+
+// Parse parseia o dataset CSV
+func Parse(r io.Reader) ([]models.NewsRecord, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ','
+
+	// Pular cabeçalho
+	_, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []models.NewsRecord
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) >= 5 {
+			newsRecord := models.NewsRecord{
+				TitleFake: record[0],
+				FakeText:  record[1],
+				LinkFake:  record[2],
+				TrueText:  record[3],
+				LinkTrue:  record[4],
+			}
+			records = append(records, newsRecord)
+		}
+	}
+
+	return records, nil
+}
+
+// WriteCSV grava registros no mesmo formato CSV lido por Parse
+func WriteCSV(w io.Writer, records []models.NewsRecord) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		row := []string{record.TitleFake, record.FakeText, record.LinkFake, record.TrueText, record.LinkTrue}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// FromPairs converte os pares do FakeTrue.Br em documentos: cada registro gera um
+// documento "fake" e um "true" (textos vazios são ignorados) no mesmo grupo,
+// para que os dois lados do par fiquem sempre no mesmo fold
+func FromPairs(records []models.NewsRecord) []models.Document {
+	var docs []models.Document
+	for i, record := range records {
+		pair := strconv.Itoa(i + 1)
+		sides := []models.Document{
+			{ID: pair + "-fake", Label: "fake", Title: record.TitleFake, Text: record.FakeText, URL: record.LinkFake},
+			{ID: pair + "-true", Label: "true", Text: record.TrueText, URL: record.LinkTrue},
+		}
+		for _, doc := range sides {
+			if strings.TrimSpace(doc.Text) == "" {
+				continue
+			}
+			doc.Source = host(doc.URL)
+			doc.Metadata = map[string]string{models.GroupKey: "pair-" + pair}
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// ToPairs converte documentos "fake" e "true" em registros do FakeTrue.Br.
+// Documentos do mesmo grupo (ex.: lidos por FromPairs) voltam a formar um par;
+// os demais ocupam um registro com apenas o lado correspondente preenchido.
+func ToPairs(docs []models.Document) ([]models.NewsRecord, error) {
+	var records []models.NewsRecord
+	byGroup := make(map[string]int)
+	for _, doc := range docs {
+		if doc.Label != "fake" && doc.Label != "true" {
+			return nil, fmt.Errorf("o formato do FakeTrue.Br aceita apenas os rótulos fake e true (documento %s: %q)", doc.ID, doc.Label)
+		}
+
+		index, ok := byGroup[doc.Group()]
+		if !ok || (doc.Label == "fake" && records[index].FakeText != "") || (doc.Label == "true" && records[index].TrueText != "") {
+			index = len(records)
+			records = append(records, models.NewsRecord{})
+			byGroup[doc.Group()] = index
+		}
+
+		record := &records[index]
+		if doc.Label == "fake" {
+			record.TitleFake, record.FakeText, record.LinkFake = doc.Title, doc.Text, doc.URL
+		} else {
+			record.TrueText, record.LinkTrue = doc.Text, doc.URL
+		}
+	}
+	return records, nil
+}
+
+// host retorna o domínio de uma URL (vazio se inválida)
+func host(link string) string {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// FromArticles converte artigos coletados em documentos candidatos com o rótulo informado
+func FromArticles(articles []crawler.Article, label string) ([]models.Document, error) {
+	if strings.TrimSpace(label) == "" {
+		return nil, fmt.Errorf("rótulo vazio")
+	}
+
+	var docs []models.Document
+	for _, article := range articles {
+		docs = append(docs, models.Document{
+			ID:     article.URL,
+			Label:  label,
+			Title:  article.Title,
+			Text:   article.Text,
+			URL:    article.URL,
+			Source: host(article.URL),
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,156 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// documentColumns são as colunas do formato de documentos rotulados (CSV ou TSV);
+// colunas extras viram metadados
+var documentColumns = []string{"id", "label", "text", "title", "url", "source", "date"}
+
+// columnAliases são nomes alternativos aceitos na leitura
+var columnAliases = map[string]string{"link": "url"}
+
+// ReadDelimited lê documentos rotulados de um CSV (comma = ',') ou TSV (comma = '\t')
+// com cabeçalho; as colunas "label" e "text" são obrigatórias
+func ReadDelimited(r io.Reader, comma rune) ([]models.Document, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	if comma == '\t' {
+		reader.LazyQuotes = true
+	}
+
+	head, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range head {
+		name = normalizeColumn(name)
+		if alias, ok := columnAliases[name]; ok {
+			name = alias
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["label"]; !ok {
+		return nil, fmt.Errorf("coluna label ausente no cabeçalho")
+	}
+	if _, ok := columns["text"]; !ok {
+		return nil, fmt.Errorf("coluna text ausente no cabeçalho")
+	}
+
+	var docs []models.Document
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		doc := models.Document{
+			ID:     field("id"),
+			Label:  field("label"),
+			Text:   field("text"),
+			Title:  field("title"),
+			URL:    field("url"),
+			Source: field("source"),
+			Date:   field("date"),
+		}
+		if doc.Label == "" {
+			continue
+		}
+		if doc.ID == "" {
+			doc.ID = strconv.Itoa(line - 1)
+		}
+		for name, i := range columns {
+			if isDocumentColumn(name) || i >= len(row) || row[i] == "" {
+				continue
+			}
+			if doc.Metadata == nil {
+				doc.Metadata = make(map[string]string)
+			}
+			doc.Metadata[name] = row[i]
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// WriteDelimited grava documentos no formato lido por ReadDelimited
+// (os metadados viram colunas extras, em ordem alfabética)
+func WriteDelimited(w io.Writer, docs []models.Document, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	extra := metadataKeys(docs)
+	if err := writer.Write(append(append([]string(nil), documentColumns...), extra...)); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		row := []string{doc.ID, doc.Label, doc.Text, doc.Title, doc.URL, doc.Source, doc.Date}
+		if comma == '\t' {
+			// TSV não admite quebras de linha nem tabulações dentro dos campos
+			for i := range row {
+				row[i] = strings.Join(strings.Fields(row[i]), " ")
+			}
+		}
+		for _, key := range extra {
+			row = append(row, doc.Metadata[key])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// normalizeColumn normaliza o nome de uma coluna do cabeçalho
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
+
+// isDocumentColumn indica se a coluna é um campo de models.Document
+func isDocumentColumn(name string) bool {
+	for _, column := range documentColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataKeys retorna as chaves de metadados presentes nos documentos, em ordem alfabética
+func metadataKeys(docs []models.Document) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, doc := range docs {
+		for key := range doc.Metadata {
+			if !seen[key] && !isDocumentColumn(key) {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
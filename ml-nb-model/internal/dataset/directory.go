@@ -0,0 +1,70 @@
+package dataset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/input"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// ReadDirectory lê um corpus organizado em um diretório por rótulo
+// (raiz/<rótulo>/<arquivo>). Cada arquivo é um documento; HTML, PDF e texto
+// puro são detectados pelo conteúdo. Arquivos e diretórios ocultos são ignorados.
+func ReadDirectory(root string) ([]models.Document, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []models.Document
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		label := entry.Name()
+
+		var paths []string
+		err := filepath.WalkDir(filepath.Join(root, label), func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			content, err := input.Load(path, input.FormatAuto)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			id, _ := filepath.Rel(root, path)
+			docs = append(docs, models.Document{
+				ID:       filepath.ToSlash(id),
+				Label:    label,
+				Text:     content.Text,
+				Metadata: map[string]string{"path": path, "format": content.Format},
+			})
+		}
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("nenhum documento em %s (esperado: um subdiretório por rótulo)", root)
+	}
+	return docs, nil
+}
@@ -0,0 +1,117 @@
+package dataset
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// jsonDocument é uma linha JSONL: os campos de models.Document com valores de
+// qualquer tipo JSON (ex.: id numérico); campos desconhecidos viram metadados
+type jsonDocument map[string]any
+
+// ReadJSONL lê documentos de um arquivo JSON Lines (um objeto por linha)
+func ReadJSONL(r io.Reader) ([]models.Document, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+
+	var docs []models.Document
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var object jsonDocument
+		if err := json.Unmarshal([]byte(text), &object); err != nil {
+			return nil, fmt.Errorf("linha %d: %v", line, err)
+		}
+		doc := object.document()
+		if doc.Label == "" {
+			continue
+		}
+		if doc.ID == "" {
+			doc.ID = strconv.Itoa(line)
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, scanner.Err()
+}
+
+// document converte o objeto JSON em documento
+func (o jsonDocument) document() models.Document {
+	doc := models.Document{
+		ID:     o.text("id"),
+		Text:   o.text("text"),
+		Title:  o.text("title"),
+		URL:    o.text("url"),
+		Label:  o.text("label"),
+		Source: o.text("source"),
+		Date:   o.text("date"),
+	}
+	if doc.URL == "" {
+		doc.URL = o.text("link")
+	}
+
+	add := func(key string, value any) {
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]string)
+		}
+		doc.Metadata[key] = jsonText(value)
+	}
+	if metadata, ok := o["metadata"].(map[string]any); ok {
+		for key, value := range metadata {
+			add(key, value)
+		}
+	}
+	for key, value := range o {
+		if key != "metadata" && key != "link" && !isDocumentColumn(key) {
+			add(key, value)
+		}
+	}
+	return doc
+}
+
+// text retorna o campo como texto
+func (o jsonDocument) text(key string) string {
+	value, ok := o[key]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(jsonText(value))
+}
+
+// jsonText converte um valor JSON em texto (números sem notação exponencial
+// quando inteiros, objetos e listas como JSON)
+func jsonText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// WriteJSONL grava documentos em JSON Lines
+func WriteJSONL(w io.Writer, docs []models.Document) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, doc := range docs {
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/crawler"
+	"github.com/souza/esw-008/ml-nb-model/internal/input"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Formatos de dataset suportados
+const (
+	FormatAuto      = "auto"      // detectado pela extensão, pelo cabeçalho ou por ser diretório
+	FormatFakeTrue  = "faketrue"  // CSV de pares do FakeTrue.Br
+	FormatCSV       = "csv"       // CSV de documentos rotulados (colunas label e text)
+	FormatTSV       = "tsv"       // TSV de documentos rotulados
+	FormatJSONL     = "jsonl"     // JSON Lines, um documento por linha
+	FormatDirectory = "directory" // um subdiretório por rótulo
+)
+
+// Formats lista os formatos aceitos por Load
+var Formats = []string{FormatAuto, FormatFakeTrue, FormatCSV, FormatTSV, FormatJSONL, FormatDirectory}
+
+// Load carrega documentos de uma URL (usando o cache do crawler), de um arquivo
+// ou de um diretório, convertendo o formato informado para models.Document
+func Load(source string, format string) ([]models.Document, error) {
+	docs, _, err := LoadWithRecords(source, format)
+	return docs, err
+}
+
+// LoadWithRecords carrega os documentos como Load e, do mesmo conteúdo (lido ou
+// baixado uma única vez), os registros de pares do FakeTrue.Br sem descartar
+// campos vazios; os registros são nil se o dataset estiver em outro formato
+func LoadWithRecords(source string, format string) ([]models.Document, []models.NewsRecord, error) {
+	if format == FormatDirectory || (format == FormatAuto && isDirectory(source)) {
+		docs, err := ReadDirectory(source)
+		return docs, nil, err
+	}
+
+	data, err := readSource(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if format == FormatAuto {
+		format = DetectFormat(source, data)
+	}
+	if format != FormatFakeTrue {
+		docs, err := Read(bytes.NewReader(data), format)
+		return docs, nil, err
+	}
+	records, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return FromPairs(records), records, nil
+}
+
+// readSource lê o conteúdo de uma URL (usando o cache do crawler) ou de um arquivo
+func readSource(source string) ([]byte, error) {
+	if input.IsURL(source) {
+		resp, err := crawler.Fetch(source)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+	return os.ReadFile(source)
+}
+
+// Read converte o conteúdo no formato informado (exceto auto e directory) em documentos
+func Read(r io.Reader, format string) ([]models.Document, error) {
+	switch format {
+	case FormatFakeTrue:
+		records, err := Parse(r)
+		if err != nil {
+			return nil, err
+		}
+		return FromPairs(records), nil
+	case FormatCSV:
+		return ReadDelimited(r, ',')
+	case FormatTSV:
+		return ReadDelimited(r, '\t')
+	case FormatJSONL:
+		return ReadJSONL(r)
+	}
+	return nil, fmt.Errorf("formato de dataset desconhecido: %s (use %s)", format, strings.Join(Formats, ", "))
+}
+
+// DetectFormat detecta o formato pela extensão do arquivo ou, para CSV, pelo
+// cabeçalho (colunas label e text indicam documentos rotulados)
+func DetectFormat(source string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(source)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".tsv", ".tab":
+		return FormatTSV
+	}
+
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		return FormatJSONL
+	}
+	head, err := csv.NewReader(bytes.NewReader(data)).Read()
+	if err == nil {
+		columns := make(map[string]bool)
+		for _, name := range head {
+			columns[normalizeColumn(name)] = true
+		}
+		if columns["label"] && columns["text"] {
+			return FormatCSV
+		}
+	}
+	return FormatFakeTrue
+}
+
+// Write grava documentos no formato informado; com FormatAuto, o formato vem da
+// extensão (.jsonl, .tsv) e, para CSV, documentos apenas "fake"/"true" são
+// gravados no formato do FakeTrue.Br
+func Write(w io.Writer, path string, docs []models.Document, format string) error {
+	if format == FormatAuto {
+		format = DetectFormat(path, nil)
+		if format == FormatFakeTrue {
+			for _, doc := range docs {
+				if doc.Label != "fake" && doc.Label != "true" {
+					format = FormatCSV
+					break
+				}
+			}
+		}
+	}
+
+	switch format {
+	case FormatFakeTrue:
+		records, err := ToPairs(docs)
+		if err != nil {
+			return err
+		}
+		return WriteCSV(w, records)
+	case FormatCSV:
+		return WriteDelimited(w, docs, ',')
+	case FormatTSV:
+		return WriteDelimited(w, docs, '\t')
+	case FormatJSONL:
+		return WriteJSONL(w, docs)
+	}
+	return fmt.Errorf("formato de saída desconhecido: %s", format)
+}
+
+// isDirectory indica se o caminho é um diretório local
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
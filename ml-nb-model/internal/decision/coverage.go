@@ -0,0 +1,85 @@
+package decision
+
+import "github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+
+// Outcome resume a aplicação de uma política a predições com rótulo conhecido
+type Outcome struct {
+	Total    int
+	Decided  int     // predições com veredito
+	Correct  int     // vereditos corretos
+	Coverage float64 // fração de predições com veredito (0-1)
+	Accuracy float64 // acurácia entre as predições com veredito (0-1)
+	Cost     float64 // custo médio por documento (erros e abstenções)
+}
+
+// Evaluate aplica a política às predições e calcula cobertura, acurácia e custo.
+// Cada erro custa o custo configurado para a classe prevista (1 por padrão) e
+// cada abstenção custa AbstainCost.
+func Evaluate(policy Policy, predictions []evaluation.Prediction) Outcome {
+	outcome := Outcome{Total: len(predictions)}
+	totalCost := 0.0
+
+	for _, p := range predictions {
+		decision := policy.Decide(p.Result.Probabilities)
+		if decision.Inconclusive() {
+			totalCost += policy.AbstainCost
+			continue
+		}
+		outcome.Decided++
+		if decision.Label == p.Actual {
+			outcome.Correct++
+		} else {
+			totalCost += policy.cost(decision.Label)
+		}
+	}
+
+	if outcome.Total > 0 {
+		outcome.Coverage = float64(outcome.Decided) / float64(outcome.Total)
+		outcome.Cost = totalCost / float64(outcome.Total)
+	}
+	if outcome.Decided > 0 {
+		outcome.Accuracy = float64(outcome.Correct) / float64(outcome.Decided)
+	}
+	return outcome
+}
+
+// CurvePoint representa um ponto da curva cobertura × acurácia
+type CurvePoint struct {
+	Threshold float64 // limiar uniforme de probabilidade (0-100)
+	Outcome
+}
+
+// Curve varre limiares uniformes (substituindo os limiares da política base,
+// mas mantendo seus custos) e retorna a cobertura e a acurácia de cada um
+func Curve(base Policy, predictions []evaluation.Prediction, thresholds []float64) []CurvePoint {
+	var curve []CurvePoint
+	for _, threshold := range thresholds {
+		policy := base
+		policy.MinProbability = threshold
+		policy.Thresholds = nil
+		curve = append(curve, CurvePoint{Threshold: threshold, Outcome: Evaluate(policy, predictions)})
+	}
+	return curve
+}
+
+// ThresholdForAccuracy retorna o menor limiar da curva (maior cobertura) cuja
+// acurácia atinge o alvo (limiares em ordem crescente); ok é falso se nenhum ponto atingir
+func ThresholdForAccuracy(curve []CurvePoint, target float64) (CurvePoint, bool) {
+	for _, point := range curve {
+		if point.Decided > 0 && point.Accuracy >= target {
+			return point, true
+		}
+	}
+	return CurvePoint{}, false
+}
+
+// CheapestThreshold retorna o ponto da curva de menor custo médio
+func CheapestThreshold(curve []CurvePoint) CurvePoint {
+	best := curve[0]
+	for _, point := range curve[1:] {
+		if point.Cost < best.Cost {
+			best = point
+		}
+	}
+	return best
+}
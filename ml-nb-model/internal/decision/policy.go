@@ -0,0 +1,122 @@
+package decision
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Inconclusive é o rótulo emitido quando a política se abstém de decidir
+const Inconclusive = "inconclusive"
+
+// Policy define como transformar probabilidades (0-100) em um veredito
+type Policy struct {
+	MinProbability float64            // probabilidade mínima padrão para emitir uma classe (0-100)
+	Thresholds     map[string]float64 // probabilidade mínima por classe (sobrepõe MinProbability)
+	Costs          map[string]float64 // custo de um erro ao prever cada classe (vazio = decidir pela maior probabilidade)
+	AbstainCost    float64            // custo de declarar inconclusivo, comparado ao custo esperado (0 = desativado)
+}
+
+// DefaultPolicy exige pelo menos 60% de probabilidade para emitir um veredito
+func DefaultPolicy() Policy {
+	return Policy{MinProbability: 60}
+}
+
+// Decision representa o veredito da política para uma predição
+type Decision struct {
+	Label       string  // classe decidida ou Inconclusive
+	Candidate   string  // classe escolhida antes dos limiares (mais provável ou de menor custo)
+	Probability float64 // probabilidade da classe candidata (0-100)
+	Reason      string  // motivo da abstenção (vazio quando há veredito)
+}
+
+// Inconclusive indica se a política se absteve
+func (d Decision) Inconclusive() bool {
+	return d.Label == Inconclusive
+}
+
+// Threshold retorna a probabilidade mínima exigida para a classe
+func (p Policy) Threshold(label string) float64 {
+	if t, ok := p.Thresholds[label]; ok {
+		return t
+	}
+	return p.MinProbability
+}
+
+// cost retorna o custo de um erro ao prever a classe (1 se não configurado)
+func (p Policy) cost(label string) float64 {
+	if c, ok := p.Costs[label]; ok {
+		return c
+	}
+	return 1
+}
+
+// ExpectedCost retorna o custo esperado de prever a classe: P(erro) · custo
+func (p Policy) ExpectedCost(probs map[string]float64, label string) float64 {
+	return (100 - probs[label]) / 100 * p.cost(label)
+}
+
+// Decide aplica a política às probabilidades de uma predição. Sem custos, a
+// candidata é a classe mais provável; com custos, é a de menor custo esperado,
+// e a política se abstém se esse custo superar o custo de abstenção. Em ambos os
+// casos a candidata só é emitida se atingir o limiar de probabilidade da classe.
+func (p Policy) Decide(probs map[string]float64) Decision {
+	labels := make([]string, 0, len(probs))
+	for label := range probs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	if len(labels) == 0 {
+		return Decision{Label: Inconclusive, Reason: "sem probabilidades"}
+	}
+
+	var decision Decision
+	if len(p.Costs) > 0 {
+		bestCost := math.Inf(1)
+		for _, label := range labels {
+			if cost := p.ExpectedCost(probs, label); cost < bestCost {
+				decision.Candidate, bestCost = label, cost
+			}
+		}
+		decision.Probability = probs[decision.Candidate]
+		if p.AbstainCost > 0 && bestCost > p.AbstainCost {
+			decision.Label = Inconclusive
+			decision.Reason = fmt.Sprintf("custo esperado %.3f acima do custo de abstenção %.3f", bestCost, p.AbstainCost)
+			return decision
+		}
+	} else {
+		for _, label := range labels {
+			if decision.Candidate == "" || probs[label] > decision.Probability {
+				decision.Candidate, decision.Probability = label, probs[label]
+			}
+		}
+	}
+
+	if threshold := p.Threshold(decision.Candidate); decision.Probability < threshold {
+		decision.Label = Inconclusive
+		decision.Reason = fmt.Sprintf("P(%s) = %.2f%% abaixo do limiar de %.2f%%", decision.Candidate, decision.Probability, threshold)
+		return decision
+	}
+
+	decision.Label = decision.Candidate
+	return decision
+}
+
+// ParseAssignments interpreta valores no formato classe=número (ex.: "fake=70")
+func ParseAssignments(values []string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	for _, value := range values {
+		label, number, found := strings.Cut(value, "=")
+		if !found || strings.TrimSpace(label) == "" {
+			return nil, fmt.Errorf("valor inválido %q (use classe=número)", value)
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor inválido %q: %w", value, err)
+		}
+		result[strings.TrimSpace(label)] = parsed
+	}
+	return result, nil
+}
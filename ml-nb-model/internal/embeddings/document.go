@@ -0,0 +1,145 @@
+package embeddings
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Ponderações das palavras no embedding de documento
+const (
+	WeightingMean  = "mean"  // média simples dos vetores das palavras
+	WeightingTFIDF = "tfidf" // média ponderada por tf × idf
+)
+
+// Embedder calcula o embedding de um documento como a média (simples ou
+// ponderada por TF-IDF) dos vetores das suas palavras, padronizada (média 0 e
+// desvio 1 por dimensão nos textos de treinamento) depois de Fit
+type Embedder struct {
+	Vectors   *Vectors
+	Weighting string
+	IDF       map[string]float64 // idf de cada palavra (calculado por Fit)
+	Documents int                // documentos usados no cálculo do idf
+	Mean      []float64          // média de cada dimensão nos textos de treinamento
+	Scale     []float64          // desvio padrão de cada dimensão (1 se nulo)
+}
+
+// NewEmbedder cria um Embedder sobre os vetores com a ponderação informada
+func NewEmbedder(vectors *Vectors, weighting string) (*Embedder, error) {
+	if weighting != WeightingMean && weighting != WeightingTFIDF {
+		return nil, fmt.Errorf("ponderação desconhecida: %s (use mean ou tfidf)", weighting)
+	}
+	return &Embedder{Vectors: vectors, Weighting: weighting}, nil
+}
+
+// Fit calcula o idf das palavras nos textos de treinamento
+func (e *Embedder) Fit(texts []string) {
+	df := make(map[string]int)
+	for _, text := range texts {
+		seen := make(map[string]bool)
+		for _, token := range utils.PreprocessText(text) {
+			if !seen[token] {
+				seen[token] = true
+				df[token]++
+			}
+		}
+	}
+
+	e.Documents = len(texts)
+	n := float64(len(texts))
+	e.IDF = make(map[string]float64, len(df))
+	for word, count := range df {
+		e.IDF[word] = math.Log((n+1)/(float64(count)+1)) + 1
+	}
+
+	// Padronização: os vetores de palavras costumam compartilhar uma direção
+	// comum, e as médias de documentos diferentes ficam muito parecidas
+	e.Mean, e.Scale = nil, nil
+	dim := e.Dim()
+	mean := make([]float64, dim)
+	sq := make([]float64, dim)
+	for _, text := range texts {
+		for d, value := range e.Embed(text) {
+			mean[d] += value
+			sq[d] += value * value
+		}
+	}
+	scale := make([]float64, dim)
+	for d := range mean {
+		if n > 0 {
+			mean[d] /= n
+			sq[d] /= n
+		}
+		scale[d] = math.Sqrt(math.Max(sq[d]-mean[d]*mean[d], 0))
+		if scale[d] < 1e-12 {
+			scale[d] = 1
+		}
+	}
+	e.Mean, e.Scale = mean, scale
+}
+
+// Dim retorna a dimensão dos embeddings
+func (e *Embedder) Dim() int {
+	return e.Vectors.Dim
+}
+
+// Components retorna a parcela de cada palavra do texto no embedding do
+// documento (peso da palavra × seu vetor, dividido pelo desvio de cada dimensão
+// quando padronizado); a soma das parcelas é o embedding, a menos da média
+// subtraída. Palavras sem vetor são ignoradas.
+func (e *Embedder) Components(text string) map[string][]float64 {
+	counts := make(map[string]float64)
+	for _, token := range utils.PreprocessText(text) {
+		if _, ok := e.Vectors.Vector(token); ok {
+			counts[token]++
+		}
+	}
+
+	weights := make(map[string]float64, len(counts))
+	total := 0.0
+	for word, count := range counts {
+		weight := count
+		if e.Weighting == WeightingTFIDF {
+			// Palavras que não apareceram no treinamento recebem o maior idf
+			idf, ok := e.IDF[word]
+			if !ok {
+				idf = math.Log(float64(e.Documents)+1) + 1
+			}
+			weight *= idf
+		}
+		weights[word] = weight
+		total += weight
+	}
+
+	components := make(map[string][]float64, len(weights))
+	for word, weight := range weights {
+		vector, _ := e.Vectors.Vector(word)
+		component := make([]float64, len(vector))
+		for d, value := range vector {
+			component[d] = weight / total * value
+			if e.Scale != nil {
+				component[d] /= e.Scale[d]
+			}
+		}
+		components[word] = component
+	}
+	return components
+}
+
+// Embed retorna o embedding do documento (a média padronizada, ou o vetor
+// nulo antes da padronização, se nenhuma palavra tiver vetor)
+func (e *Embedder) Embed(text string) []float64 {
+	embedding := make([]float64, e.Dim())
+	for _, component := range e.Components(text) {
+		for d, value := range component {
+			embedding[d] += value
+		}
+	}
+	if e.Mean != nil {
+		for d := range embedding {
+			embedding[d] -= e.Mean[d] / e.Scale[d]
+		}
+	}
+	return embedding
+}
@@ -0,0 +1,111 @@
+package embeddings
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// cooccurrence é uma entrada não nula da matriz de coocorrência
+type cooccurrence struct {
+	i, j  int
+	count float64
+}
+
+// trainGloVe treina vetores GloVe: conta as coocorrências da janela (pesadas
+// por 1/distância) e ajusta w_i·w̃_j + b_i + b̃_j ≈ log X_ij por mínimos
+// quadrados ponderados, com AdaGrad. O vetor final é w + w̃.
+func trainGloVe(sentences [][]string, vocab vocabulary, opts Options) *Vectors {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	n, dim := len(vocab.words), opts.Dim
+
+	counts := make(map[[2]int]float64)
+	for _, sentence := range sentences {
+		ids := vocab.ids(sentence)
+		for pos, center := range ids {
+			for ctx := pos + 1; ctx <= pos+opts.Window && ctx < len(ids); ctx++ {
+				weight := 1 / float64(ctx-pos)
+				counts[[2]int{center, ids[ctx]}] += weight
+				counts[[2]int{ids[ctx], center}] += weight
+			}
+		}
+	}
+	entries := make([]cooccurrence, 0, len(counts))
+	for key, count := range counts {
+		entries = append(entries, cooccurrence{i: key[0], j: key[1], count: count})
+	}
+	// Ordem determinística antes do embaralhamento
+	sortCooccurrences(entries)
+
+	newParams := func() ([][]float64, [][]float64, []float64, []float64) {
+		w := make([][]float64, n)
+		g := make([][]float64, n)
+		for i := range w {
+			w[i] = make([]float64, dim)
+			g[i] = make([]float64, dim)
+			for d := range w[i] {
+				w[i][d] = (rng.Float64() - 0.5) / float64(dim)
+				g[i][d] = 1
+			}
+		}
+		b := make([]float64, n)
+		gb := make([]float64, n)
+		for i := range gb {
+			gb[i] = 1
+		}
+		return w, g, b, gb
+	}
+	w, gw, b, gbw := newParams()
+	c, gc, bc, gbc := newParams()
+
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		rng.Shuffle(len(entries), func(a, z int) { entries[a], entries[z] = entries[z], entries[a] })
+		loss := 0.0
+		for _, e := range entries {
+			weight := 1.0
+			if e.count < opts.XMax {
+				weight = math.Pow(e.count/opts.XMax, opts.Alpha)
+			}
+			diff := dot(w[e.i], c[e.j]) + b[e.i] + bc[e.j] - math.Log(e.count)
+			loss += 0.5 * weight * diff * diff
+			fdiff := weight * diff
+
+			for d := 0; d < dim; d++ {
+				g1 := fdiff * c[e.j][d]
+				g2 := fdiff * w[e.i][d]
+				w[e.i][d] -= opts.LearningRate * g1 / math.Sqrt(gw[e.i][d])
+				c[e.j][d] -= opts.LearningRate * g2 / math.Sqrt(gc[e.j][d])
+				gw[e.i][d] += g1 * g1
+				gc[e.j][d] += g2 * g2
+			}
+			b[e.i] -= opts.LearningRate * fdiff / math.Sqrt(gbw[e.i])
+			bc[e.j] -= opts.LearningRate * fdiff / math.Sqrt(gbc[e.j])
+			gbw[e.i] += fdiff * fdiff
+			gbc[e.j] += fdiff * fdiff
+		}
+		if len(entries) > 0 && (epoch%5 == 0 || epoch == opts.Epochs-1) {
+			models.Logf("GloVe: época %d/%d, perda média %.4f (%d coocorrências)\n", epoch+1, opts.Epochs, loss/float64(len(entries)), len(entries))
+		}
+	}
+
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, dim)
+		for d := range matrix[i] {
+			matrix[i][d] = w[i][d] + c[i][d]
+		}
+	}
+	return NewVectors(vocab.words, matrix)
+}
+
+// sortCooccurrences ordena as entradas por (i, j)
+func sortCooccurrences(entries []cooccurrence) {
+	sort.Slice(entries, func(a, z int) bool {
+		if entries[a].i != entries[z].i {
+			return entries[a].i < entries[z].i
+		}
+		return entries[a].j < entries[z].j
+	})
+}
@@ -0,0 +1,134 @@
+package embeddings
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// trainSkipGram treina vetores com o skip-gram do word2vec com amostragem
+// negativa: para cada par (palavra, contexto) da janela, aproxima o vetor da
+// palavra do vetor de saída do contexto e o afasta de Negative palavras
+// sorteadas pela distribuição unigrama elevada a 3/4
+func trainSkipGram(sentences [][]string, vocab vocabulary, opts Options) *Vectors {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	n, dim := len(vocab.words), opts.Dim
+
+	input := make([][]float64, n)
+	output := make([][]float64, n)
+	for i := range input {
+		input[i] = make([]float64, dim)
+		output[i] = make([]float64, dim)
+		for d := range input[i] {
+			input[i][d] = (rng.Float64() - 0.5) / float64(dim)
+		}
+	}
+
+	// Distribuição acumulada das amostras negativas
+	cumulative := make([]float64, n)
+	sum := 0.0
+	for i, count := range vocab.counts {
+		sum += math.Pow(float64(count), 0.75)
+		cumulative[i] = sum
+	}
+	negative := func() int {
+		return sort.SearchFloat64s(cumulative, rng.Float64()*sum)
+	}
+
+	// Probabilidade de manter cada palavra na subamostragem
+	keep := make([]float64, n)
+	for i, count := range vocab.counts {
+		keep[i] = 1
+		if opts.Sample > 0 {
+			f := float64(count) / float64(vocab.total)
+			keep[i] = math.Min(1, (math.Sqrt(f/opts.Sample)+1)*opts.Sample/f)
+		}
+	}
+
+	corpus := make([][]int, len(sentences))
+	for i, sentence := range sentences {
+		corpus[i] = vocab.ids(sentence)
+	}
+
+	totalSteps := float64(opts.Epochs * vocab.total)
+	step := 0.0
+	grad := make([]float64, dim)
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		loss, pairs := 0.0, 0
+		for _, sentence := range corpus {
+			var kept []int
+			for _, id := range sentence {
+				if rng.Float64() < keep[id] {
+					kept = append(kept, id)
+				}
+			}
+			step += float64(len(sentence))
+			lr := math.Max(opts.LearningRate*(1-step/totalSteps), opts.LearningRate*1e-4)
+
+			for pos, center := range kept {
+				window := 1 + rng.Intn(opts.Window)
+				for ctx := pos - window; ctx <= pos+window; ctx++ {
+					if ctx < 0 || ctx >= len(kept) || ctx == pos {
+						continue
+					}
+					for d := range grad {
+						grad[d] = 0
+					}
+					vector := input[center]
+					for k := 0; k <= opts.Negative; k++ {
+						target, label := kept[ctx], 1.0
+						if k > 0 {
+							target, label = negative(), 0
+							if target == kept[ctx] {
+								continue
+							}
+						}
+						out := output[target]
+						p := sigmoid(dot(vector, out))
+						if label == 1 {
+							loss -= math.Log(math.Max(p, 1e-10))
+						} else {
+							loss -= math.Log(math.Max(1-p, 1e-10))
+						}
+						g := lr * (label - p)
+						for d := range grad {
+							grad[d] += g * out[d]
+							out[d] += g * vector[d]
+						}
+					}
+					for d := range vector {
+						vector[d] += grad[d]
+					}
+					pairs++
+				}
+			}
+		}
+		if pairs > 0 && (epoch%5 == 0 || epoch == opts.Epochs-1) {
+			models.Logf("Skip-gram: época %d/%d, perda média %.4f (%d pares)\n", epoch+1, opts.Epochs, loss/float64(pairs), pairs)
+		}
+	}
+
+	return NewVectors(vocab.words, input)
+}
+
+// sigmoid função logística, limitada para evitar overflow
+func sigmoid(x float64) float64 {
+	if x > 20 {
+		return 1
+	}
+	if x < -20 {
+		return 0
+	}
+	return 1 / (1 + math.Exp(-x))
+}
+
+// dot calcula o produto escalar
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
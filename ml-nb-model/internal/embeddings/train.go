@@ -0,0 +1,128 @@
+package embeddings
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Métodos de treinamento dos vetores
+const (
+	MethodSkipGram = "sgns"  // word2vec skip-gram com amostragem negativa
+	MethodGloVe    = "glove" // GloVe (fatoração da matriz de coocorrência)
+)
+
+// Methods lista os métodos de treinamento disponíveis
+var Methods = []string{MethodSkipGram, MethodGloVe}
+
+// Options configura o treinamento dos vetores
+type Options struct {
+	Method       string
+	Dim          int     // dimensão dos vetores
+	Window       int     // distância máxima entre palavra e contexto
+	Epochs       int     // passagens sobre o corpus
+	MinCount     int     // frequência mínima de uma palavra no corpus
+	LearningRate float64 // taxa de aprendizado inicial (0 = padrão do método)
+	Negative     int     // amostras negativas por par (skip-gram)
+	Sample       float64 // limiar de subamostragem de palavras frequentes (skip-gram; 0 = desativada)
+	XMax         float64 // coocorrência de peso máximo (GloVe)
+	Alpha        float64 // expoente da função de peso (GloVe)
+	Seed         int64
+}
+
+// DefaultOptions retorna a configuração padrão: skip-gram com 50 dimensões
+func DefaultOptions() Options {
+	return Options{
+		Method:   MethodSkipGram,
+		Dim:      50,
+		Window:   5,
+		Epochs:   10,
+		MinCount: 2,
+		Negative: 5,
+		Sample:   1e-3,
+		XMax:     100,
+		Alpha:    0.75,
+		Seed:     1,
+	}
+}
+
+// Train treina vetores de palavras nos textos, tokenizados com PreprocessText
+func Train(texts []string, opts Options) (*Vectors, error) {
+	if opts.Dim < 1 || opts.Window < 1 || opts.Epochs < 1 {
+		return nil, fmt.Errorf("dimensão, janela e épocas devem ser positivas")
+	}
+
+	sentences := make([][]string, len(texts))
+	for i, text := range texts {
+		sentences[i] = utils.PreprocessText(text)
+	}
+	vocab := buildVocab(sentences, opts.MinCount)
+	if len(vocab.words) == 0 {
+		return nil, fmt.Errorf("nenhuma palavra com frequência ≥ %d no corpus", opts.MinCount)
+	}
+
+	switch opts.Method {
+	case MethodSkipGram:
+		if opts.LearningRate <= 0 {
+			opts.LearningRate = 0.025
+		}
+		return trainSkipGram(sentences, vocab, opts), nil
+	case MethodGloVe:
+		if opts.LearningRate <= 0 {
+			opts.LearningRate = 0.05
+		}
+		return trainGloVe(sentences, vocab, opts), nil
+	}
+	return nil, fmt.Errorf("método desconhecido: %s (use sgns ou glove)", opts.Method)
+}
+
+// vocabulary é o vocabulário do treinamento: palavras com frequência mínima,
+// das mais às menos frequentes (desempate alfabético)
+type vocabulary struct {
+	words  []string
+	counts []int
+	index  map[string]int
+	total  int
+}
+
+func buildVocab(sentences [][]string, minCount int) vocabulary {
+	counts := make(map[string]int)
+	for _, sentence := range sentences {
+		for _, token := range sentence {
+			counts[token]++
+		}
+	}
+
+	var words []string
+	for word, count := range counts {
+		if count >= minCount {
+			words = append(words, word)
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+
+	vocab := vocabulary{words: words, counts: make([]int, len(words)), index: make(map[string]int, len(words))}
+	for i, word := range words {
+		vocab.index[word] = i
+		vocab.counts[i] = counts[word]
+		vocab.total += counts[word]
+	}
+	return vocab
+}
+
+// ids converte uma sentença nos índices das palavras do vocabulário (ignorando as demais)
+func (v vocabulary) ids(sentence []string) []int {
+	ids := make([]int, 0, len(sentence))
+	for _, token := range sentence {
+		if id, ok := v.index[token]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,184 @@
+package embeddings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Vectors é um conjunto de vetores densos de palavras
+type Vectors struct {
+	Dim    int
+	Words  []string
+	Matrix [][]float64 // vetor de cada palavra, na ordem de Words
+	index  map[string]int
+}
+
+// Neighbor é uma palavra próxima de outra no espaço de embeddings
+type Neighbor struct {
+	Word       string
+	Similarity float64 // similaridade de cosseno
+}
+
+// NewVectors cria o conjunto de vetores das palavras informadas
+func NewVectors(words []string, matrix [][]float64) *Vectors {
+	v := &Vectors{Words: words, Matrix: matrix, index: make(map[string]int, len(words))}
+	if len(matrix) > 0 {
+		v.Dim = len(matrix[0])
+	}
+	for i, word := range words {
+		v.index[word] = i
+	}
+	return v
+}
+
+// Len retorna o número de palavras
+func (v *Vectors) Len() int {
+	return len(v.Words)
+}
+
+// Vector retorna o vetor de uma palavra
+func (v *Vectors) Vector(word string) ([]float64, bool) {
+	i, ok := v.index[word]
+	if !ok {
+		return nil, false
+	}
+	return v.Matrix[i], true
+}
+
+// Similar retorna as n palavras mais próximas (cosseno) da palavra informada
+func (v *Vectors) Similar(word string, n int) []Neighbor {
+	target, ok := v.Vector(word)
+	if !ok {
+		return nil
+	}
+
+	var neighbors []Neighbor
+	for i, other := range v.Words {
+		if other == word {
+			continue
+		}
+		neighbors = append(neighbors, Neighbor{Word: other, Similarity: cosine(target, v.Matrix[i])})
+	}
+	sort.SliceStable(neighbors, func(i, j int) bool {
+		return neighbors[i].Similarity > neighbors[j].Similarity
+	})
+	if len(neighbors) > n {
+		neighbors = neighbors[:n]
+	}
+	return neighbors
+}
+
+// Save grava os vetores no formato texto do word2vec: um cabeçalho
+// "<palavras> <dimensão>" e uma linha "palavra v1 v2 ..." por palavra
+func (v *Vectors) Save(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "%d %d\n", v.Len(), v.Dim)
+	for i, word := range v.Words {
+		writer.WriteString(word)
+		for _, value := range v.Matrix[i] {
+			writer.WriteString(" ")
+			writer.WriteString(strconv.FormatFloat(value, 'f', 6, 64))
+		}
+		writer.WriteString("\n")
+	}
+	return writer.Flush()
+}
+
+// Load lê vetores no formato texto do word2vec (com cabeçalho) ou do GloVe
+// (sem cabeçalho); todas as linhas devem ter a mesma dimensão
+func Load(r io.Reader) (*Vectors, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+
+	var words []string
+	var matrix [][]float64
+	dim := 0
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		// Cabeçalho do word2vec: "<palavras> <dimensão>"
+		if line == 1 && len(fields) == 2 {
+			if _, err := strconv.Atoi(fields[0]); err == nil {
+				continue
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("linha %d: vetor vazio", line)
+		}
+
+		vector := make([]float64, len(fields)-1)
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: valor inválido %q", line, field)
+			}
+			vector[i] = value
+		}
+		if dim == 0 {
+			dim = len(vector)
+		} else if len(vector) != dim {
+			return nil, fmt.Errorf("linha %d: dimensão %d diferente de %d", line, len(vector), dim)
+		}
+		words = append(words, strings.ToLower(fields[0]))
+		matrix = append(matrix, vector)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("nenhum vetor encontrado")
+	}
+	return NewVectors(words, matrix), nil
+}
+
+// loaded guarda os arquivos de vetores já lidos, para que cada fold de
+// cross-validation não leia o mesmo arquivo de novo
+var (
+	loadedMu sync.Mutex
+	loaded   = make(map[string]*Vectors)
+)
+
+// LoadFile lê um arquivo de vetores pré-treinados (lido uma única vez por execução)
+func LoadFile(path string) (*Vectors, error) {
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	if vectors, ok := loaded[path]; ok {
+		return vectors, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vectors, err := Load(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	loaded[path] = vectors
+	return vectors, nil
+}
+
+// cosine calcula a similaridade de cosseno entre dois vetores
+func cosine(a, b []float64) float64 {
+	dot, na, nb := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
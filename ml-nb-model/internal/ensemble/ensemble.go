@@ -0,0 +1,269 @@
+package ensemble
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Métodos de combinação suportados
+const (
+	MethodSoft     = "soft"     // média simples das probabilidades
+	MethodWeighted = "weighted" // média ponderada (pesos fixos ou aprendidos out-of-fold)
+	MethodStacking = "stacking" // regressão logística treinada sobre predições out-of-fold
+)
+
+// Methods lista os métodos de combinação suportados
+var Methods = []string{MethodSoft, MethodWeighted, MethodStacking}
+
+// DefaultFolds é o número de folds usado para obter as predições out-of-fold dos membros
+const DefaultFolds = 5
+
+// Member representa um classificador base do ensemble
+type Member struct {
+	Name    string
+	Factory models.Factory
+	Weight  float64 // peso fixo no voto ponderado (0 = aprendido com as predições out-of-fold)
+}
+
+// Classifier combina vários classificadores (implementa models.Classifier)
+type Classifier struct {
+	Method  string
+	Members []Member
+	Folds   int
+	Weights []float64 // peso efetivo de cada membro na média e nas contribuições
+	Meta    *Stacker  // meta-classificador (apenas MethodStacking)
+	trained []models.Classifier
+}
+
+// ValidateMethod verifica o método de combinação
+func ValidateMethod(method string) error {
+	for _, known := range Methods {
+		if method == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("método de ensemble desconhecido: %s (use %s)", method, strings.Join(Methods, ", "))
+}
+
+// NewClassifier cria um ensemble com o método de combinação informado
+func NewClassifier(method string, members []Member) (*Classifier, error) {
+	if err := ValidateMethod(method); err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("o ensemble precisa de pelo menos um membro")
+	}
+	return &Classifier{Method: method, Members: members, Folds: DefaultFolds}, nil
+}
+
+// Train treina o ensemble. Os métodos weighted (sem pesos fixos) e stacking
+// obtêm antes as predições out-of-fold de cada membro para aprender os pesos
+// ou o meta-classificador; em seguida, todos os membros são treinados com todos os documentos.
+func (c *Classifier) Train(docs []models.Document) {
+	models.Logf("Treinando ensemble (%s) com %d membros...\n", c.Method, len(c.Members))
+
+	c.Weights = make([]float64, len(c.Members))
+	for i := range c.Weights {
+		c.Weights[i] = 1
+	}
+
+	needsOOF := c.Method == MethodStacking
+	if c.Method == MethodWeighted {
+		for _, member := range c.Members {
+			if member.Weight <= 0 {
+				needsOOF = true
+			}
+		}
+	}
+
+	if needsOOF {
+		models.Logf("Gerando predições out-of-fold (%d folds)...\n", c.Folds)
+		oof := make([][]evaluation.Prediction, len(c.Members))
+		for i, member := range c.Members {
+			oof[i] = evaluation.CrossValidate(docs, member.Factory, c.Folds, nil)
+		}
+
+		if c.Method == MethodStacking {
+			c.Meta = NewStacker()
+			c.Meta.Fit(oof)
+		} else {
+			for i, member := range c.Members {
+				if member.Weight > 0 {
+					c.Weights[i] = member.Weight
+				} else {
+					c.Weights[i] = accuracyWeight(oof[i])
+				}
+			}
+		}
+	} else if c.Method == MethodWeighted {
+		for i, member := range c.Members {
+			c.Weights[i] = member.Weight
+		}
+	}
+	c.normalizeWeights()
+
+	c.trained = make([]models.Classifier, len(c.Members))
+	for i, member := range c.Members {
+		c.trained[i] = member.Factory()
+		c.trained[i].Train(docs)
+	}
+
+	if c.Method != MethodStacking {
+		var weights []string
+		for i, member := range c.Members {
+			weights = append(weights, fmt.Sprintf("%s %.3f", member.Name, c.Weights[i]))
+		}
+		models.Logf("Pesos do ensemble: %s\n", strings.Join(weights, " | "))
+	}
+}
+
+// Predict combina as predições dos membros
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	results := c.MemberResults(text)
+	memberProbs := make([]map[string]float64, len(results))
+	for i, r := range results {
+		memberProbs[i] = r.Probabilities
+	}
+	probs := c.combineProbabilities(memberProbs)
+
+	result := models.ClassificationResult{Probabilities: probs}
+	for _, label := range sortedLabels(probs) {
+		if result.Label == "" || probs[label] > result.Confidence {
+			result.Label = label
+			result.Confidence = probs[label]
+		}
+	}
+
+	result.Contributions = c.combineContributions(results)
+	for _, contrib := range result.Contributions {
+		result.TopTokens = append(result.TopTokens, contrib.Token)
+		if len(result.TopTokens) >= 10 {
+			break
+		}
+	}
+	return result
+}
+
+// Probabilities combina só as probabilidades dos membros, sem as contribuições
+// dos tokens (implementa models.ProbabilityModel)
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	memberProbs := make([]map[string]float64, len(c.trained))
+	for i, classifier := range c.trained {
+		memberProbs[i] = models.Probabilities(classifier, text)
+	}
+	return c.combineProbabilities(memberProbs)
+}
+
+// combineProbabilities combina as probabilidades dos membros pelo meta-modelo
+// (stacking) ou pela média ponderada pelos pesos
+func (c *Classifier) combineProbabilities(memberProbs []map[string]float64) map[string]float64 {
+	if c.Method == MethodStacking && c.Meta != nil {
+		return c.Meta.Predict(memberProbs)
+	}
+	probs := make(map[string]float64)
+	for i, memberProb := range memberProbs {
+		for label, p := range memberProb {
+			probs[label] += c.Weights[i] * p
+		}
+	}
+	return probs
+}
+
+// MemberResults retorna a predição de cada membro treinado, na ordem de Members
+func (c *Classifier) MemberResults(text string) []models.ClassificationResult {
+	results := make([]models.ClassificationResult, len(c.trained))
+	for i, classifier := range c.trained {
+		results[i] = classifier.Predict(text)
+	}
+	return results
+}
+
+// combineContributions soma as contribuições dos membros ponderadas pelos pesos do ensemble.
+// Cada membro usa uma escala (razão de log-verossimilhança no NB, gradiente × entrada
+// no MLP, pontos percentuais na heurística), então as contribuições de cada membro são
+// antes normalizadas para norma L1 unitária: viram a fração da atribuição total do membro.
+func (c *Classifier) combineContributions(results []models.ClassificationResult) []models.TokenContribution {
+	index := make(map[string]int)
+	var combined []models.TokenContribution
+
+	for i, r := range results {
+		total := 0.0
+		for _, contrib := range r.Contributions {
+			for _, score := range contrib.Scores {
+				total += math.Abs(score)
+			}
+		}
+		if total == 0 {
+			continue
+		}
+
+		for _, contrib := range r.Contributions {
+			j, exists := index[contrib.Token]
+			if !exists {
+				j = len(combined)
+				index[contrib.Token] = j
+				combined = append(combined, models.TokenContribution{
+					Token:  contrib.Token,
+					Count:  contrib.Count,
+					Scores: make(map[string]float64),
+				})
+			}
+			for label, score := range contrib.Scores {
+				combined[j].Scores[label] += c.Weights[i] * score / total
+			}
+		}
+	}
+
+	sort.SliceStable(combined, func(a, b int) bool {
+		return combined[a].Magnitude() > combined[b].Magnitude()
+	})
+	return combined
+}
+
+// normalizeWeights faz os pesos somarem 1 (iguais se todos forem nulos)
+func (c *Classifier) normalizeWeights() {
+	sum := 0.0
+	for _, w := range c.Weights {
+		sum += w
+	}
+	for i := range c.Weights {
+		if sum > 0 {
+			c.Weights[i] /= sum
+		} else {
+			c.Weights[i] = 1 / float64(len(c.Weights))
+		}
+	}
+}
+
+// accuracyWeight calcula o peso de um membro a partir da acurácia out-of-fold:
+// log(acc / (1 − acc)), nulo para membros que não superam o acaso
+func accuracyWeight(predictions []evaluation.Prediction) float64 {
+	correct := 0
+	for _, p := range predictions {
+		if p.Result.Label == p.Actual {
+			correct++
+		}
+	}
+	if len(predictions) == 0 {
+		return 0
+	}
+
+	acc := float64(correct) / float64(len(predictions))
+	acc = math.Min(math.Max(acc, 0.01), 0.99)
+	return math.Max(math.Log(acc/(1-acc)), 0)
+}
+
+// sortedLabels retorna as classes em ordem alfabética
+func sortedLabels(probs map[string]float64) []string {
+	labels := make([]string, 0, len(probs))
+	for label := range probs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
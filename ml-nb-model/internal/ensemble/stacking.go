@@ -0,0 +1,145 @@
+package ensemble
+
+import (
+	"math"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+)
+
+// Stacker é uma regressão logística multinomial que recebe como features o
+// logit das probabilidades de cada membro para cada classe (limitado a ±10 e
+// dividido por 10, para manter as features na mesma escala do viés)
+type Stacker struct {
+	Classes      []string
+	Weights      [][]float64 // [classe][feature]; a última feature é o viés
+	L2           float64
+	LearningRate float64
+	Iterations   int
+}
+
+// NewStacker cria o meta-classificador com a configuração padrão
+func NewStacker() *Stacker {
+	return &Stacker{
+		L2:           0.01,
+		LearningRate: 0.5,
+		Iterations:   1000,
+	}
+}
+
+// Fit treina o meta-classificador por gradiente descendente sobre as predições
+// out-of-fold dos membros (oof[membro][documento], na mesma ordem para todos)
+func (s *Stacker) Fit(oof [][]evaluation.Prediction) {
+	n := len(oof[0])
+	classSet := make(map[string]bool)
+	for _, p := range oof[0] {
+		classSet[p.Actual] = true
+		for label := range p.Result.Probabilities {
+			classSet[label] = true
+		}
+	}
+	s.Classes = s.Classes[:0]
+	for label := range classSet {
+		s.Classes = append(s.Classes, label)
+	}
+	sort.Strings(s.Classes)
+
+	X := make([][]float64, n)
+	y := make([]int, n)
+	for d := 0; d < n; d++ {
+		memberProbs := make([]map[string]float64, len(oof))
+		for m := range oof {
+			memberProbs[m] = oof[m][d].Result.Probabilities
+		}
+		X[d] = s.features(memberProbs)
+		for k, label := range s.Classes {
+			if label == oof[0][d].Actual {
+				y[d] = k
+			}
+		}
+	}
+
+	dim := len(X[0])
+	s.Weights = make([][]float64, len(s.Classes))
+	for k := range s.Weights {
+		s.Weights[k] = make([]float64, dim)
+	}
+
+	grad := make([][]float64, len(s.Classes))
+	for k := range grad {
+		grad[k] = make([]float64, dim)
+	}
+	for iter := 0; iter < s.Iterations; iter++ {
+		for k := range grad {
+			for j := range grad[k] {
+				grad[k][j] = 0
+			}
+		}
+		for d, x := range X {
+			probs := s.softmax(x)
+			for k := range s.Classes {
+				diff := probs[k]
+				if k == y[d] {
+					diff--
+				}
+				for j, v := range x {
+					grad[k][j] += diff * v
+				}
+			}
+		}
+		for k := range s.Weights {
+			for j := range s.Weights[k] {
+				g := grad[k][j] / float64(n)
+				if j < dim-1 {
+					g += s.L2 * s.Weights[k][j]
+				}
+				s.Weights[k][j] -= s.LearningRate * g
+			}
+		}
+	}
+}
+
+// Predict retorna as probabilidades (0-100) combinadas pelo meta-classificador
+func (s *Stacker) Predict(memberProbs []map[string]float64) map[string]float64 {
+	probs := s.softmax(s.features(memberProbs))
+	result := make(map[string]float64, len(s.Classes))
+	for k, label := range s.Classes {
+		result[label] = probs[k] * 100
+	}
+	return result
+}
+
+// features monta o vetor logit(P_m(c)) / 10 para cada membro m e classe c, mais o viés
+func (s *Stacker) features(memberProbs []map[string]float64) []float64 {
+	var x []float64
+	for _, probs := range memberProbs {
+		for _, label := range s.Classes {
+			p := math.Min(math.Max(probs[label]/100, 1e-4), 1-1e-4)
+			logit := math.Min(math.Max(math.Log(p/(1-p)), -10), 10)
+			x = append(x, logit/10)
+		}
+	}
+	return append(x, 1)
+}
+
+// softmax calcula as probabilidades (0-1) de cada classe para o vetor de features
+func (s *Stacker) softmax(x []float64) []float64 {
+	scores := make([]float64, len(s.Classes))
+	maxScore := math.Inf(-1)
+	for k, w := range s.Weights {
+		for j, v := range x {
+			scores[k] += w[j] * v
+		}
+		maxScore = math.Max(maxScore, scores[k])
+	}
+
+	sum := 0.0
+	for k := range scores {
+		scores[k] = math.Exp(scores[k] - maxScore)
+		sum += scores[k]
+	}
+	for k := range scores {
+		scores[k] /= sum
+	}
+	return scores
+}
@@ -0,0 +1,86 @@
+package evaluation
+
+import (
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Prediction representa a predição out-of-fold de um documento
+type Prediction struct {
+	Fold   int
+	ID     string
+	Text   string
+	Actual string
+	Result models.ClassificationResult
+}
+
+// CreateFolds cria os folds para cross-validation. Os grupos de documentos
+// (Document.Group) são distribuídos entre os folds em ordem de aparição, de modo
+// que documentos do mesmo grupo nunca ficam separados entre treino e teste.
+func CreateFolds(docs []models.Document, numFolds int) []models.Fold {
+	folds := make([]models.Fold, numFolds)
+
+	// Dividir grupos em folds
+	for i, foldIndex := range AssignFolds(docs, numFolds) {
+		folds[foldIndex].Test = append(folds[foldIndex].Test, docs[i])
+	}
+
+	// Para cada fold, usar os outros como treinamento
+	for i := range folds {
+		for j, fold := range folds {
+			if j != i {
+				folds[i].Train = append(folds[i].Train, fold.Test...)
+			}
+		}
+	}
+
+	return folds
+}
+
+// AssignFolds retorna o fold de teste de cada documento, na mesma divisão usada por CreateFolds
+func AssignFolds(docs []models.Document, numFolds int) []int {
+	assignment := make([]int, len(docs))
+	groupFold := make(map[string]int)
+	for i, doc := range docs {
+		group := doc.Group()
+		foldIndex, seen := groupFold[group]
+		if !seen {
+			foldIndex = len(groupFold) % numFolds
+			groupFold[group] = foldIndex
+		}
+		assignment[i] = foldIndex
+	}
+	return assignment
+}
+
+// CrossValidate treina um classificador novo por fold e retorna as predições
+// out-of-fold de todos os documentos. progress (opcional) é chamado no início de cada fold.
+func CrossValidate(docs []models.Document, factory models.Factory, numFolds int, progress func(fold, total int)) []Prediction {
+	var predictions []Prediction
+
+	for i, fold := range CreateFolds(docs, numFolds) {
+		if progress != nil {
+			progress(i+1, numFolds)
+		}
+
+		classifier := factory()
+		classifier.Train(fold.Train)
+
+		// Testar cada documento do fold
+		for _, doc := range fold.Test {
+			if strings.TrimSpace(doc.Text) == "" || doc.Label == "" {
+				continue
+			}
+			predictions = append(predictions, Prediction{
+				Fold:   i,
+				ID:     doc.ID,
+				Text:   doc.Text,
+				Actual: doc.Label,
+				Result: classifier.Predict(doc.Text),
+			})
+		}
+	}
+
+	return predictions
+}
@@ -0,0 +1,141 @@
+package evaluation
+
+import (
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/neardup"
+)
+
+// FoldLeakage resume o vazamento de um fold: documentos de teste que têm um
+// quase-duplicado no conjunto de treinamento
+type FoldLeakage struct {
+	Fold           int
+	Test           int     // documentos de teste
+	Leaked         int     // com ao menos um quase-duplicado no treino
+	SameLabel      int     // vazados com quase-duplicado de mesmo rótulo no treino
+	CrossLabel     int     // vazados cujos quase-duplicados no treino têm só outros rótulos
+	MeanSimilarity float64 // média, entre os vazados, da maior similaridade com o treino
+}
+
+// LeakageReport resume os quase-duplicados do dataset e o vazamento entre treino e teste
+type LeakageReport struct {
+	Threshold  float64
+	Pairs      int // pares de quase-duplicados
+	SameGroup  int // pares no mesmo grupo (nunca separados entre folds)
+	SameFold   int // pares de grupos diferentes que caíram no mesmo fold
+	CrossFold  int // pares separados entre treino e teste
+	Folds      []FoldLeakage
+	Leaked     map[string]bool // IDs dos documentos de teste vazados
+	Total      FoldLeakage     // soma dos folds (Fold = -1)
+	Duplicates []neardup.Pair  // pares de quase-duplicados, por posição em docs
+}
+
+// DetectLeakage procura quase-duplicados (MinHash/LSH sobre shingles) entre os
+// documentos e conta, para a divisão em folds de CreateFolds, quantos documentos
+// de teste têm um quase-duplicado no treinamento do mesmo fold
+func DetectLeakage(docs []models.Document, numFolds int, opts neardup.Options) (LeakageReport, error) {
+	index, err := neardup.IndexDocuments(docs, opts)
+	if err != nil {
+		return LeakageReport{}, err
+	}
+
+	report := LeakageReport{
+		Threshold:  opts.Threshold,
+		Folds:      make([]FoldLeakage, numFolds),
+		Leaked:     make(map[string]bool),
+		Total:      FoldLeakage{Fold: -1},
+		Duplicates: index.Pairs(),
+	}
+	report.Pairs = len(report.Duplicates)
+
+	assignment := AssignFolds(docs, numFolds)
+	for i := range report.Folds {
+		report.Folds[i].Fold = i
+	}
+	for i, doc := range docs {
+		if usable(doc) {
+			report.Folds[assignment[i]].Test++
+		}
+	}
+
+	// Maior similaridade de cada documento com o treino do seu fold, por rótulo
+	type exposure struct {
+		best      float64
+		sameLabel bool
+	}
+	exposures := make(map[int]*exposure)
+	expose := func(test, train int, similarity float64) {
+		if !usable(docs[test]) {
+			return
+		}
+		e := exposures[test]
+		if e == nil {
+			e = &exposure{}
+			exposures[test] = e
+		}
+		if similarity > e.best {
+			e.best = similarity
+		}
+		if docs[test].Label == docs[train].Label {
+			e.sameLabel = true
+		}
+	}
+
+	for _, pair := range report.Duplicates {
+		switch {
+		case docs[pair.A].Group() == docs[pair.B].Group():
+			report.SameGroup++
+		case assignment[pair.A] == assignment[pair.B]:
+			report.SameFold++
+		default:
+			report.CrossFold++
+			expose(pair.A, pair.B, pair.Similarity)
+			expose(pair.B, pair.A, pair.Similarity)
+		}
+	}
+
+	for i, e := range exposures {
+		fold := &report.Folds[assignment[i]]
+		fold.Leaked++
+		fold.MeanSimilarity += e.best
+		if e.sameLabel {
+			fold.SameLabel++
+		} else {
+			fold.CrossLabel++
+		}
+		report.Leaked[docs[i].ID] = true
+	}
+
+	for i := range report.Folds {
+		fold := &report.Folds[i]
+		report.Total.Test += fold.Test
+		report.Total.Leaked += fold.Leaked
+		report.Total.SameLabel += fold.SameLabel
+		report.Total.CrossLabel += fold.CrossLabel
+		report.Total.MeanSimilarity += fold.MeanSimilarity
+		if fold.Leaked > 0 {
+			fold.MeanSimilarity /= float64(fold.Leaked)
+		}
+	}
+	if report.Total.Leaked > 0 {
+		report.Total.MeanSimilarity /= float64(report.Total.Leaked)
+	}
+
+	return report, nil
+}
+
+// WithoutLeaked retorna as predições dos documentos que não vazaram
+func (r LeakageReport) WithoutLeaked(predictions []Prediction) []Prediction {
+	var clean []Prediction
+	for _, p := range predictions {
+		if !r.Leaked[p.ID] {
+			clean = append(clean, p)
+		}
+	}
+	return clean
+}
+
+// usable indica se o documento entra no treinamento e na avaliação (ver models.Samples)
+func usable(doc models.Document) bool {
+	texts, _ := models.Samples([]models.Document{doc})
+	return len(texts) > 0
+}
@@ -0,0 +1,97 @@
+package evaluation
+
+import "github.com/souza/esw-008/ml-nb-model/internal/models"
+
+// CalculateMetrics calcula métricas de avaliação para qualquer conjunto de rótulos.
+// Precisão, revocação e F1 são os da classe "true" (positiva) quando os rótulos são
+// apenas "true" e "fake"; com outros rótulos, são a média macro entre as classes.
+func CalculateMetrics(predictions, actuals []string) models.Metrics {
+	confusion := make(map[string]map[string]int)
+	correct := 0
+	for i, pred := range predictions {
+		actual := actuals[i]
+		if confusion[actual] == nil {
+			confusion[actual] = make(map[string]int)
+		}
+		confusion[actual][pred]++
+		if pred == actual {
+			correct++
+		}
+	}
+
+	classes := models.SortedLabels(append(append([]string(nil), actuals...), predictions...))
+	metrics := models.Metrics{
+		Classes:   classes,
+		PerClass:  make(map[string]models.ClassMetrics, len(classes)),
+		Confusion: confusion,
+	}
+	if len(predictions) > 0 {
+		metrics.Accuracy = float64(correct) / float64(len(predictions))
+	}
+
+	binary := true
+	for _, class := range classes {
+		if class != "true" && class != "fake" {
+			binary = false
+		}
+	}
+
+	for _, class := range classes {
+		tp := confusion[class][class]
+		var fp, fn, support int
+		for actual, row := range confusion {
+			for pred, count := range row {
+				if actual == class {
+					support += count
+					if pred != class {
+						fn += count
+					}
+				} else if pred == class {
+					fp += count
+				}
+			}
+		}
+
+		perClass := models.ClassMetrics{Support: support}
+		if tp+fp > 0 {
+			perClass.Precision = float64(tp) / float64(tp+fp)
+		}
+		if tp+fn > 0 {
+			perClass.Recall = float64(tp) / float64(tp+fn)
+		}
+		if perClass.Precision+perClass.Recall > 0 {
+			perClass.F1Score = 2 * (perClass.Precision * perClass.Recall) / (perClass.Precision + perClass.Recall)
+		}
+		metrics.PerClass[class] = perClass
+
+		if !binary {
+			metrics.Precision += perClass.Precision / float64(len(classes))
+			metrics.Recall += perClass.Recall / float64(len(classes))
+			metrics.F1Score += perClass.F1Score / float64(len(classes))
+		}
+	}
+
+	if binary {
+		positive := metrics.PerClass["true"]
+		metrics.Precision = positive.Precision
+		metrics.Recall = positive.Recall
+		metrics.F1Score = positive.F1Score
+	}
+
+	return metrics
+}
+
+// Evaluate calcula as métricas das predições out-of-fold, incluindo o
+// erro de calibração esperado (ECE) com o número de faixas informado
+func Evaluate(predictions []Prediction, bins int) models.Metrics {
+	predicted := make([]string, len(predictions))
+	actuals := make([]string, len(predictions))
+	for i, p := range predictions {
+		predicted[i] = p.Result.Label
+		actuals[i] = p.Actual
+	}
+
+	metrics := CalculateMetrics(predicted, actuals)
+	_, metrics.ECE = Reliability(predictions, bins)
+	return metrics
+}
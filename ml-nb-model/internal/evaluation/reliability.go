@@ -0,0 +1,56 @@
+package evaluation
+
+import "math"
+
+// ReliabilityBin representa uma faixa do diagrama de confiabilidade
+type ReliabilityBin struct {
+	Lower      float64 // limite inferior da confiança (0-1)
+	Upper      float64 // limite superior da confiança (0-1)
+	Count      int     // número de predições na faixa
+	Confidence float64 // confiança média das predições na faixa (0-1)
+	Accuracy   float64 // fração de acertos na faixa (0-1)
+}
+
+// Reliability calcula o diagrama de confiabilidade da classe prevista e o
+// erro de calibração esperado: ECE = Σ (n_b / N) · |acurácia_b − confiança_b|
+func Reliability(predictions []Prediction, numBins int) ([]ReliabilityBin, float64) {
+	if numBins <= 0 {
+		numBins = 10
+	}
+
+	bins := make([]ReliabilityBin, numBins)
+	for i := range bins {
+		bins[i].Lower = float64(i) / float64(numBins)
+		bins[i].Upper = float64(i+1) / float64(numBins)
+	}
+
+	for _, p := range predictions {
+		confidence := p.Result.Probabilities[p.Result.Label] / 100
+		index := int(confidence * float64(numBins))
+		if index >= numBins {
+			index = numBins - 1
+		}
+		if index < 0 {
+			index = 0
+		}
+
+		bins[index].Count++
+		bins[index].Confidence += confidence
+		if p.Result.Label == p.Actual {
+			bins[index].Accuracy++
+		}
+	}
+
+	ece := 0.0
+	for i := range bins {
+		if bins[i].Count == 0 {
+			continue
+		}
+		n := float64(bins[i].Count)
+		bins[i].Confidence /= n
+		bins[i].Accuracy /= n
+		ece += n / float64(len(predictions)) * math.Abs(bins[i].Accuracy-bins[i].Confidence)
+	}
+
+	return bins, ece
+}
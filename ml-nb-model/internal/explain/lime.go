@@ -0,0 +1,227 @@
+package explain
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/segment"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Modos de perturbação suportados
+const (
+	ModeWord     = "word"
+	ModeSentence = "sentence"
+)
+
+// ProbabilityFunc retorna as probabilidades (0-100) de cada classe para um texto
+type ProbabilityFunc func(text string) map[string]float64
+
+// FromClassifier adapta qualquer models.Classifier para uma ProbabilityFunc; com
+// models.ProbabilityModel, as perturbações não calculam as contribuições dos tokens
+func FromClassifier(classifier models.Classifier) ProbabilityFunc {
+	return func(text string) map[string]float64 {
+		return models.Probabilities(classifier, text)
+	}
+}
+
+// Options configura a explicação
+type Options struct {
+	Mode        string  // ModeWord (remove palavras) ou ModeSentence (remove sentenças)
+	Samples     int     // número de textos perturbados
+	KernelWidth float64 // largura do kernel exponencial sobre a distância de cosseno
+	Ridge       float64 // regularização L2 do modelo substituto
+	Top         int     // número de features retornadas
+	Target      string  // classe explicada (vazio = classe prevista para o texto original)
+	Seed        int64
+}
+
+// DefaultOptions retorna a configuração padrão (remoção de palavras, 1000 amostras)
+func DefaultOptions() Options {
+	return Options{
+		Mode:        ModeWord,
+		Samples:     1000,
+		KernelWidth: 0.25,
+		Ridge:       1.0,
+		Top:         10,
+		Seed:        1,
+	}
+}
+
+// Feature representa uma feature interpretável e seu peso no modelo local
+type Feature struct {
+	Name   string  // palavra ou sentença
+	Weight float64 // efeito de manter a feature sobre P(alvo), em pontos percentuais
+}
+
+// Explanation representa a explicação local de uma predição
+type Explanation struct {
+	Target     string
+	Original   float64   // P(alvo) para o texto original (0-100)
+	Intercept  float64   // P(alvo) prevista pelo substituto com todas as features removidas
+	Score      float64   // R² ponderado do modelo substituto
+	Features   []Feature // features mais influentes (maior |peso| primeiro)
+	NumSamples int
+}
+
+// unit é um trecho do texto original associado a uma feature (ou a nenhuma, -1)
+type unit struct {
+	text    string
+	feature int
+}
+
+// wordRegex separa o texto em palavras e separadores, preservando o texto original
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+|[^\p{L}\p{N}]+`)
+
+// Explain explica a predição de fn para o texto ajustando um modelo linear local
+// (estilo LIME): gera variações do texto removendo palavras ou sentenças, pondera
+// cada variação pela proximidade ao original e ajusta uma regressão ridge ponderada
+// de P(alvo) sobre a presença de cada feature. Retorna erro se a largura do kernel
+// não for positiva ou se a classe alvo não estiver entre as classes de fn.
+func Explain(fn ProbabilityFunc, text string, opts Options) (*Explanation, error) {
+	if opts.KernelWidth <= 0 {
+		return nil, fmt.Errorf("largura do kernel inválida: %g (deve ser maior que zero)", opts.KernelWidth)
+	}
+	units, features := splitUnits(text, opts.Mode)
+
+	original := fn(text)
+	target := opts.Target
+	if target == "" {
+		target = argmax(original)
+	}
+	prob, ok := original[target]
+	if !ok {
+		return nil, fmt.Errorf("classe alvo desconhecida: %q", target)
+	}
+
+	explanation := &Explanation{Target: target, Original: prob}
+	d := len(features)
+	if d == 0 || opts.Samples <= 0 {
+		return explanation, nil
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	// Gerar amostras perturbadas (a primeira é o próprio texto)
+	samples := make([][]float64, opts.Samples)
+	targets := make([]float64, opts.Samples)
+	weights := make([]float64, opts.Samples)
+	for s := range samples {
+		z := make([]float64, d)
+		for i := range z {
+			z[i] = 1
+		}
+		if s > 0 {
+			remove := rng.Intn(d) + 1
+			for _, i := range rng.Perm(d)[:remove] {
+				z[i] = 0
+			}
+		}
+
+		kept := 0.0
+		for _, v := range z {
+			kept += v
+		}
+		// Distância de cosseno entre a amostra binária e o vetor de uns
+		distance := 1 - math.Sqrt(kept/float64(d))
+
+		samples[s] = z
+		weights[s] = math.Exp(-distance * distance / (opts.KernelWidth * opts.KernelWidth))
+		targets[s] = fn(rebuild(units, z))[target]
+	}
+
+	coef, intercept := weightedRidge(samples, targets, weights, opts.Ridge)
+	explanation.Intercept = intercept
+	explanation.Score = weightedR2(samples, targets, weights, coef, intercept)
+	explanation.NumSamples = len(samples)
+
+	for i, name := range features {
+		explanation.Features = append(explanation.Features, Feature{Name: name, Weight: coef[i]})
+	}
+	sort.SliceStable(explanation.Features, func(i, j int) bool {
+		return math.Abs(explanation.Features[i].Weight) > math.Abs(explanation.Features[j].Weight)
+	})
+	if opts.Top > 0 && len(explanation.Features) > opts.Top {
+		explanation.Features = explanation.Features[:opts.Top]
+	}
+
+	return explanation, nil
+}
+
+// splitUnits divide o texto em unidades e identifica as features interpretáveis.
+// No modo palavra, cada palavra distinta (após normalização e remoção de stop words)
+// é uma feature e remover a feature remove todas as suas ocorrências.
+func splitUnits(text string, mode string) ([]unit, []string) {
+	var units []unit
+	var features []string
+
+	if mode == ModeSentence {
+		last := 0
+		for _, s := range segment.Split(text, segment.LevelSentence) {
+			if s.Start > last {
+				units = append(units, unit{text: text[last:s.Start], feature: -1})
+			}
+			units = append(units, unit{text: s.Text, feature: len(features)})
+			features = append(features, s.Text)
+			last = s.End
+		}
+		if last < len(text) {
+			units = append(units, unit{text: text[last:], feature: -1})
+		}
+		return units, features
+	}
+
+	index := make(map[string]int)
+	stopWords := utils.GetStopWords()
+	for _, piece := range wordRegex.FindAllString(text, -1) {
+		word := strings.ToLower(piece)
+		tokens := utils.PreprocessText(word)
+		if len(tokens) != 1 || stopWords[word] {
+			units = append(units, unit{text: piece, feature: -1})
+			continue
+		}
+		i, exists := index[tokens[0]]
+		if !exists {
+			i = len(features)
+			index[tokens[0]] = i
+			features = append(features, tokens[0])
+		}
+		units = append(units, unit{text: piece, feature: i})
+	}
+	return units, features
+}
+
+// rebuild monta o texto mantendo apenas as features presentes na amostra
+func rebuild(units []unit, z []float64) string {
+	var sb strings.Builder
+	for _, u := range units {
+		if u.feature < 0 || z[u.feature] > 0 {
+			sb.WriteString(u.text)
+		} else {
+			sb.WriteString(" ")
+		}
+	}
+	return sb.String()
+}
+
+// argmax retorna a classe de maior probabilidade (desempate alfabético)
+func argmax(probs map[string]float64) string {
+	labels := make([]string, 0, len(probs))
+	for label := range probs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	best := ""
+	for _, label := range labels {
+		if best == "" || probs[label] > probs[best] {
+			best = label
+		}
+	}
+	return best
+}
@@ -0,0 +1,129 @@
+package explain
+
+import "math"
+
+// weightedRidge ajusta y ≈ X·β + b por mínimos quadrados ponderados com
+// regularização L2 em β (o intercepto não é regularizado)
+func weightedRidge(X [][]float64, y, w []float64, lambda float64) ([]float64, float64) {
+	n, d := len(X), len(X[0])
+
+	// Médias ponderadas para centralizar os dados
+	sumW := 0.0
+	meanX := make([]float64, d)
+	meanY := 0.0
+	for s := 0; s < n; s++ {
+		sumW += w[s]
+		meanY += w[s] * y[s]
+		for i, v := range X[s] {
+			meanX[i] += w[s] * v
+		}
+	}
+	meanY /= sumW
+	for i := range meanX {
+		meanX[i] /= sumW
+	}
+
+	// Montar o sistema (Xcᵀ W Xc + λI) β = Xcᵀ W yc
+	A := make([][]float64, d)
+	for i := range A {
+		A[i] = make([]float64, d)
+		A[i][i] = lambda
+	}
+	rhs := make([]float64, d)
+	xc := make([]float64, d)
+	for s := 0; s < n; s++ {
+		for i := range xc {
+			xc[i] = X[s][i] - meanX[i]
+		}
+		yc := y[s] - meanY
+		for i := 0; i < d; i++ {
+			if xc[i] == 0 {
+				continue
+			}
+			wi := w[s] * xc[i]
+			rhs[i] += wi * yc
+			row := A[i]
+			for j := i; j < d; j++ {
+				row[j] += wi * xc[j]
+			}
+		}
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j < i; j++ {
+			A[i][j] = A[j][i]
+		}
+	}
+
+	beta := solveCholesky(A, rhs)
+
+	intercept := meanY
+	for i := range beta {
+		intercept -= beta[i] * meanX[i]
+	}
+	return beta, intercept
+}
+
+// solveCholesky resolve A·x = b para A simétrica positiva definida
+func solveCholesky(A [][]float64, b []float64) []float64 {
+	d := len(A)
+	L := make([][]float64, d)
+	for i := range L {
+		L[i] = make([]float64, i+1)
+		for j := 0; j <= i; j++ {
+			sum := A[i][j]
+			for k := 0; k < j; k++ {
+				sum -= L[i][k] * L[j][k]
+			}
+			if i == j {
+				L[i][i] = math.Sqrt(math.Max(sum, 1e-12))
+			} else {
+				L[i][j] = sum / L[j][j]
+			}
+		}
+	}
+
+	// L·z = b
+	z := make([]float64, d)
+	for i := 0; i < d; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= L[i][k] * z[k]
+		}
+		z[i] = sum / L[i][i]
+	}
+
+	// Lᵀ·x = z
+	x := make([]float64, d)
+	for i := d - 1; i >= 0; i-- {
+		sum := z[i]
+		for k := i + 1; k < d; k++ {
+			sum -= L[k][i] * x[k]
+		}
+		x[i] = sum / L[i][i]
+	}
+	return x
+}
+
+// weightedR2 calcula o coeficiente de determinação ponderado do modelo linear
+func weightedR2(X [][]float64, y, w, beta []float64, intercept float64) float64 {
+	sumW, meanY := 0.0, 0.0
+	for s := range y {
+		sumW += w[s]
+		meanY += w[s] * y[s]
+	}
+	meanY /= sumW
+
+	var ssRes, ssTot float64
+	for s := range y {
+		pred := intercept
+		for i, v := range X[s] {
+			pred += beta[i] * v
+		}
+		ssRes += w[s] * (y[s] - pred) * (y[s] - pred)
+		ssTot += w[s] * (y[s] - meanY) * (y[s] - meanY)
+	}
+	if ssTot == 0 {
+		return 1
+	}
+	return 1 - ssRes/ssTot
+}
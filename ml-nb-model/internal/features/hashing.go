@@ -0,0 +1,108 @@
+package features
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// HashingVectorizer converte textos em vetores esparsos de dimensão fixa pelo
+// truque do hashing: cada termo (palavra ou n-grama de palavras) vai para o índice
+// hash(termo) mod Dim, sem vocabulário. A memória não depende do corpus e palavras
+// nunca vistas no treinamento também têm índice na predição.
+type HashingVectorizer struct {
+	Dim       int  // dimensão do espaço de características
+	NGrams    int  // maior n-grama de palavras (1 = apenas palavras)
+	Signed    bool // sinal ±1 por termo, para que colisões tendam a se cancelar
+	Sublinear bool // usar 1 + log(|tf|) em vez da contagem bruta
+	Normalize bool // normalizar os vetores para norma L2 unitária
+}
+
+// DefaultHashDim é a dimensão padrão do espaço de hashing (2^18)
+const DefaultHashDim = 1 << 18
+
+// NewHashingVectorizer cria um vetorizador por hashing com sinal, apenas
+// palavras, tf sublinear e normalização L2
+func NewHashingVectorizer(dim int) *HashingVectorizer {
+	if dim <= 0 {
+		dim = DefaultHashDim
+	}
+	return &HashingVectorizer{
+		Dim:       dim,
+		NGrams:    1,
+		Signed:    true,
+		Sublinear: true,
+		Normalize: true,
+	}
+}
+
+// Size retorna a dimensão do espaço de características
+func (h *HashingVectorizer) Size() int {
+	return h.Dim
+}
+
+// Terms retorna os termos do texto: os tokens de PreprocessText seguidos dos
+// n-gramas de 2 até NGrams palavras consecutivas, unidas por espaço
+func (h *HashingVectorizer) Terms(text string) []string {
+	tokens := utils.PreprocessText(text)
+	terms := append([]string(nil), tokens...)
+	for n := 2; n <= h.NGrams; n++ {
+		for i := 0; i+n <= len(tokens); i++ {
+			terms = append(terms, strings.Join(tokens[i:i+n], " "))
+		}
+	}
+	return terms
+}
+
+// Bucket retorna o índice do termo e seu sinal (+1, ou ±1 por um bit do hash com Signed)
+func (h *HashingVectorizer) Bucket(term string) (int, float64) {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(term))
+	sum := hasher.Sum32()
+
+	sign := 1.0
+	if h.Signed && sum>>31 == 1 {
+		sign = -1
+	}
+	return int(sum % uint32(h.Dim)), sign
+}
+
+// Transform converte um texto em vetor esparso; termos que colidem no mesmo
+// índice somam suas contagens (com sinal)
+func (h *HashingVectorizer) Transform(text string) Vector {
+	counts := make(map[int]float64)
+	for _, term := range h.Terms(text) {
+		index, sign := h.Bucket(term)
+		counts[index] += sign
+	}
+
+	vector := Vector{Indices: make([]int, 0, len(counts))}
+	for index, value := range counts {
+		if value != 0 {
+			vector.Indices = append(vector.Indices, index)
+		}
+	}
+	sort.Ints(vector.Indices)
+
+	norm := 0.0
+	vector.Values = make([]float64, len(vector.Indices))
+	for k, index := range vector.Indices {
+		value := counts[index]
+		if h.Sublinear {
+			value = math.Copysign(1+math.Log(math.Abs(value)), value)
+		}
+		vector.Values[k] = value
+		norm += value * value
+	}
+
+	if h.Normalize && norm > 0 {
+		norm = math.Sqrt(norm)
+		for k := range vector.Values {
+			vector.Values[k] /= norm
+		}
+	}
+	return vector
+}
@@ -0,0 +1,123 @@
+package features
+
+import (
+	"math"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Vector é um vetor esparso com índices em ordem crescente
+type Vector struct {
+	Indices []int
+	Values  []float64
+}
+
+// Dot calcula o produto escalar com um vetor denso
+func (v Vector) Dot(weights []float64) float64 {
+	sum := 0.0
+	for k, i := range v.Indices {
+		sum += v.Values[k] * weights[i]
+	}
+	return sum
+}
+
+// Vectorizer converte textos em vetores esparsos de frequência de termos
+// sobre um vocabulário aprendido nos textos de treinamento
+type Vectorizer struct {
+	Vocab       map[string]int
+	Terms       []string // termo de cada índice
+	MinDF       int      // frequência mínima de documentos para um termo entrar no vocabulário
+	MaxFeatures int      // número máximo de termos (os mais frequentes); 0 = sem limite
+	Sublinear   bool     // usar 1 + log(tf) em vez da contagem bruta
+	Normalize   bool     // normalizar os vetores para norma L2 unitária
+}
+
+// NewVectorizer cria um vetorizador com tf sublinear e normalização L2
+func NewVectorizer() *Vectorizer {
+	return &Vectorizer{
+		Vocab:     make(map[string]int),
+		MinDF:     1,
+		Sublinear: true,
+		Normalize: true,
+	}
+}
+
+// Fit constrói o vocabulário a partir dos textos de treinamento
+func (v *Vectorizer) Fit(texts []string) {
+	df := make(map[string]int)
+	for _, text := range texts {
+		seen := make(map[string]bool)
+		for _, token := range utils.PreprocessText(text) {
+			if !seen[token] {
+				seen[token] = true
+				df[token]++
+			}
+		}
+	}
+
+	var terms []string
+	for term, count := range df {
+		if count >= v.MinDF {
+			terms = append(terms, term)
+		}
+	}
+
+	// Manter os termos mais frequentes (desempate alfabético)
+	sort.Slice(terms, func(i, j int) bool {
+		if df[terms[i]] != df[terms[j]] {
+			return df[terms[i]] > df[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	if v.MaxFeatures > 0 && len(terms) > v.MaxFeatures {
+		terms = terms[:v.MaxFeatures]
+	}
+	sort.Strings(terms)
+
+	v.Terms = terms
+	v.Vocab = make(map[string]int, len(terms))
+	for i, term := range terms {
+		v.Vocab[term] = i
+	}
+}
+
+// Size retorna o número de termos do vocabulário
+func (v *Vectorizer) Size() int {
+	return len(v.Terms)
+}
+
+// Transform converte um texto em vetor esparso (termos fora do vocabulário são ignorados)
+func (v *Vectorizer) Transform(text string) Vector {
+	counts := make(map[int]float64)
+	for _, token := range utils.PreprocessText(text) {
+		if index, exists := v.Vocab[token]; exists {
+			counts[index]++
+		}
+	}
+
+	vector := Vector{Indices: make([]int, 0, len(counts))}
+	for index := range counts {
+		vector.Indices = append(vector.Indices, index)
+	}
+	sort.Ints(vector.Indices)
+
+	norm := 0.0
+	vector.Values = make([]float64, len(vector.Indices))
+	for k, index := range vector.Indices {
+		value := counts[index]
+		if v.Sublinear {
+			value = 1 + math.Log(value)
+		}
+		vector.Values[k] = value
+		norm += value * value
+	}
+
+	if v.Normalize && norm > 0 {
+		norm = math.Sqrt(norm)
+		for k := range vector.Values {
+			vector.Values[k] /= norm
+		}
+	}
+	return vector
+}
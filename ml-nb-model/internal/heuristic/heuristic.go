@@ -0,0 +1,114 @@
+package heuristic
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// DebunkTerms são termos típicos de desmentidos ou de fake news
+var DebunkTerms = []string{"boato", "falso", "mentira", "desmentido", "fake news"}
+
+// Matches retorna os termos de DebunkTerms presentes no texto
+func Matches(text string) []string {
+	lowerText := strings.ToLower(text)
+	var found []string
+	for _, term := range DebunkTerms {
+		if strings.Contains(lowerText, term) {
+			found = append(found, term)
+		}
+	}
+	return found
+}
+
+// Classifier transforma a heurística de termos em um classificador
+// (implementa models.Classifier), para uso como membro de um ensemble
+type Classifier struct {
+	Strength  float64  // P(Untrusted) atribuída quando um único termo é encontrado (0-100)
+	Untrusted string   // rótulo favorecido pelos termos suspeitos (ex.: "fake")
+	Classes   []string // classes do treinamento
+}
+
+// NewClassifier cria o classificador heurístico que favorece o rótulo untrusted
+func NewClassifier(untrusted string) *Classifier {
+	return &Classifier{Strength: 80, Untrusted: untrusted}
+}
+
+// Train guarda as classes do treinamento; a heurística não é aprendida
+func (c *Classifier) Train(docs []models.Document) {
+	c.Classes = models.Labels(docs)
+}
+
+// Applies indica se a heurística decide o texto: há termos suspeitos e o rótulo
+// Untrusted está entre as classes (sem ele, a heurística não tem a quem favorecer)
+func Applies(text string, untrusted string, classes []string) bool {
+	if len(Matches(text)) == 0 {
+		return false
+	}
+	for _, class := range classes {
+		if class == untrusted {
+			return len(classes) > 1
+		}
+	}
+	return false
+}
+
+// Predict retorna a distribuição uniforme entre as classes sem termos suspeitos;
+// com n termos, P(Untrusted) cresce de Strength em direção a 100,
+// P(Untrusted) = 100 − (100 − Strength) / n, e o restante é dividido entre as
+// demais classes. Sem o rótulo Untrusted no treinamento, a distribuição é sempre uniforme.
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	classes := c.Classes
+	if len(classes) == 0 {
+		classes = []string{c.Untrusted}
+	}
+	uniform := 100 / float64(len(classes))
+
+	var found []string
+	if Applies(text, c.Untrusted, classes) {
+		found = Matches(text)
+	}
+	if len(found) == 0 {
+		result := models.ClassificationResult{Confidence: uniform, Probabilities: make(map[string]float64, len(classes))}
+		for _, class := range classes {
+			result.Probabilities[class] = uniform
+			if result.Label == "" && class != c.Untrusted {
+				result.Label = class
+			}
+		}
+		if result.Label == "" {
+			result.Label = classes[0]
+		}
+		return result
+	}
+
+	probUntrusted := 100 - (100-c.Strength)/float64(len(found))
+	others := float64(len(classes) - 1)
+	result := models.ClassificationResult{
+		Label:         c.Untrusted,
+		Confidence:    probUntrusted,
+		Probabilities: make(map[string]float64, len(classes)),
+	}
+	for _, class := range classes {
+		result.Probabilities[class] = (100 - probUntrusted) / others
+	}
+	result.Probabilities[c.Untrusted] = probUntrusted
+
+	sort.Strings(found)
+	score := (probUntrusted - uniform) / float64(len(found))
+	for _, term := range found {
+		scores := make(map[string]float64, len(classes))
+		for _, class := range classes {
+			scores[class] = -score / others
+		}
+		scores[c.Untrusted] = score
+		result.TopTokens = append(result.TopTokens, term)
+		result.Contributions = append(result.Contributions, models.TokenContribution{
+			Token:  term,
+			Count:  1,
+			Scores: scores,
+		})
+	}
+	return result
+}
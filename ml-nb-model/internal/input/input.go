@@ -0,0 +1,111 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/crawler"
+	"github.com/souza/esw-008/ml-nb-model/internal/pdf"
+)
+
+// Formatos de entrada suportados
+const (
+	FormatAuto = "auto"
+	FormatHTML = "html"
+	FormatText = "text"
+	FormatPDF  = "pdf"
+)
+
+// Content representa o texto obtido de uma fonte de entrada
+type Content struct {
+	Origin string // URL, caminho do arquivo ou "stdin"
+	Format string // formato efetivamente usado na extração
+	Text   string
+}
+
+// Load obtém o texto de uma URL, de um arquivo local ou da entrada padrão ("-").
+// Com format = FormatAuto, o formato é detectado pelo conteúdo.
+func Load(source string, format string) (*Content, error) {
+	var data []byte
+	var err error
+	origin := source
+
+	switch {
+	case source == "-":
+		origin = "stdin"
+		data, err = io.ReadAll(os.Stdin)
+	case IsURL(source):
+		var resp *crawler.CachedResponse
+		resp, err = crawler.Fetch(source)
+		if err == nil {
+			data = resp.Body
+			if format == FormatAuto && strings.Contains(resp.Headers.Get("Content-Type"), "application/pdf") {
+				format = FormatPDF
+			}
+		}
+	default:
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "" || format == FormatAuto {
+		format = DetectFormat(data)
+	}
+
+	text, err := Extract(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao extrair texto (%s) de %s: %w", format, origin, err)
+	}
+
+	return &Content{Origin: origin, Format: format, Text: text}, nil
+}
+
+// IsURL indica se a fonte é uma URL HTTP(S)
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// DetectFormat identifica o formato pelo conteúdo dos dados
+func DetectFormat(data []byte) string {
+	if bytes.HasPrefix(bytes.TrimLeft(data, " \r\n\t"), []byte("%PDF-")) {
+		return FormatPDF
+	}
+	if strings.HasPrefix(http.DetectContentType(data), "text/html") {
+		return FormatHTML
+	}
+	return FormatText
+}
+
+// Extract extrai o texto dos dados no formato informado
+func Extract(data []byte, format string) (string, error) {
+	switch format {
+	case FormatPDF:
+		return pdf.ExtractText(data)
+	case FormatHTML:
+		return crawler.ExtractText(bytes.NewReader(data))
+	case FormatText:
+		return normalizeText(data), nil
+	}
+	return "", fmt.Errorf("formato desconhecido: %q (use auto, html, text ou pdf)", format)
+}
+
+// normalizeText decodifica texto puro (UTF-8 ou, se inválido, Latin-1)
+func normalizeText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	if utf8.Valid(data) {
+		return strings.TrimSpace(string(data))
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return strings.TrimSpace(string(runes))
+}
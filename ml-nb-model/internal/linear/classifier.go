@@ -0,0 +1,303 @@
+package linear
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/features"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/stylometry"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Funções de perda suportadas
+const (
+	LossLogistic = "logistic" // regressão logística
+	LossHinge    = "hinge"    // SVM linear (Pegasos)
+)
+
+// Regularizações suportadas
+const (
+	PenaltyL1 = "l1"
+	PenaltyL2 = "l2"
+)
+
+// Options configura o treinamento de um modelo linear
+type Options struct {
+	Loss    string  // LossLogistic ou LossHinge
+	Penalty string  // PenaltyL1 ou PenaltyL2 (a SVM usa sempre L2)
+	Lambda  float64 // força da regularização (na SVM, 0 = 1/n, com n documentos de treinamento)
+	Epochs  int     // passagens sobre os dados de treinamento
+	Eta0    float64 // taxa de aprendizado inicial (apenas regressão logística)
+	Seed    int64   // semente do embaralhamento
+	// Stylometry acrescenta as características de estilo padronizadas aos vetores de termos
+	Stylometry bool
+}
+
+// Classifier é um classificador linear sobre vetores esparsos de termos
+// (implementa models.Classifier). Com duas classes é treinado um único modelo
+// binário; com mais classes, um modelo por classe (um contra todos).
+type Classifier struct {
+	Options
+	Name       string
+	Vectorizer *features.Vectorizer
+	Style      *stylometry.Scaler // padronização das características de estilo (nil sem Stylometry)
+	Classes    []string
+	Weights    [][]float64 // [classe][termo]
+	Bias       []float64   // viés de cada classe
+}
+
+// NewLogisticRegression cria uma regressão logística com regularização L1 ou L2
+// (lambda <= 0 usa o padrão da regularização)
+func NewLogisticRegression(penalty string, lambda float64) (*Classifier, error) {
+	if penalty != PenaltyL1 && penalty != PenaltyL2 {
+		return nil, fmt.Errorf("regularização desconhecida: %s (use l1 ou l2)", penalty)
+	}
+	if lambda <= 0 {
+		lambda = 1e-4
+		if penalty == PenaltyL1 {
+			lambda = 1e-5
+		}
+	}
+	return &Classifier{
+		Name:       "Regressão Logística",
+		Vectorizer: features.NewVectorizer(),
+		Options: Options{
+			Loss:    LossLogistic,
+			Penalty: penalty,
+			Lambda:  lambda,
+			Epochs:  10,
+			Eta0:    0.5,
+			Seed:    1,
+		},
+	}, nil
+}
+
+// NewSVM cria uma SVM linear treinada com Pegasos (lambda <= 0 usa 1/n no treinamento)
+func NewSVM(lambda float64) *Classifier {
+	return &Classifier{
+		Name:       "SVM Linear",
+		Vectorizer: features.NewVectorizer(),
+		Options: Options{
+			Loss:    LossHinge,
+			Penalty: PenaltyL2,
+			Lambda:  lambda,
+			Epochs:  10,
+			Seed:    1,
+		},
+	}
+}
+
+// Train constrói o vocabulário e treina os pesos de cada classe
+func (c *Classifier) Train(docs []models.Document) {
+	texts, labels := models.Samples(docs)
+	c.Vectorizer.Fit(texts)
+	c.Style = nil
+	if c.Stylometry {
+		c.Style = &stylometry.Scaler{}
+		c.Style.Fit(texts)
+	}
+
+	X := make([]features.Vector, len(texts))
+	for i, text := range texts {
+		X[i] = c.vectorize(text)
+	}
+
+	classSet := make(map[string]bool)
+	for _, label := range labels {
+		classSet[label] = true
+	}
+	c.Classes = c.Classes[:0]
+	for label := range classSet {
+		c.Classes = append(c.Classes, label)
+	}
+	sort.Strings(c.Classes)
+
+	opts := c.Options
+	if opts.Lambda <= 0 {
+		opts.Lambda = 1 / math.Max(float64(len(X)), 1)
+	}
+
+	models.Logf("Treinando %s (%s, λ=%g) com %d documentos e %d termos...\n",
+		c.Name, opts.Penalty, opts.Lambda, len(X), c.Vectorizer.Size())
+
+	dim := c.dim()
+	c.Weights = make([][]float64, len(c.Classes))
+	c.Bias = make([]float64, len(c.Classes))
+
+	fit := func(positive string) ([]float64, float64) {
+		y := make([]float64, len(labels))
+		for i, label := range labels {
+			if label == positive {
+				y[i] = 1
+			} else {
+				y[i] = -1
+			}
+		}
+		if c.Loss == LossHinge {
+			return trainPegasos(X, y, dim, opts)
+		}
+		return trainLogistic(X, y, dim, opts)
+	}
+
+	switch len(c.Classes) {
+	case 0:
+	case 1:
+		c.Weights[0] = make([]float64, dim)
+		c.Bias[0] = 1
+	case 2:
+		// Um único modelo binário: a primeira classe recebe os pesos opostos
+		w, b := fit(c.Classes[1])
+		c.Weights[1], c.Bias[1] = w, b
+		c.Weights[0] = make([]float64, dim)
+		for i, v := range w {
+			c.Weights[0][i] = -v
+		}
+		c.Bias[0] = -b
+	default:
+		for k, label := range c.Classes {
+			c.Weights[k], c.Bias[k] = fit(label)
+		}
+	}
+
+	nonZero := 0
+	for i := 0; i < dim; i++ {
+		for _, w := range c.Weights {
+			if w[i] != 0 {
+				nonZero++
+				break
+			}
+		}
+	}
+	models.Logf("Treinamento %s concluído! (%d/%d termos com peso não nulo)\n", c.Name, nonZero, dim)
+}
+
+// dim retorna a dimensão dos vetores: termos seguidos das características de estilo
+func (c *Classifier) dim() int {
+	if c.Style != nil {
+		return c.Vectorizer.Size() + len(stylometry.Features)
+	}
+	return c.Vectorizer.Size()
+}
+
+// vectorize converte um texto no vetor esparso de termos, acrescido das
+// características de estilo (índices a partir do tamanho do vocabulário)
+func (c *Classifier) vectorize(text string) features.Vector {
+	x := c.Vectorizer.Transform(text)
+	if c.Style == nil {
+		return x
+	}
+	offset := c.Vectorizer.Size()
+	for i, value := range c.Style.Transform(text) {
+		x.Indices = append(x.Indices, offset+i)
+		x.Values = append(x.Values, value)
+	}
+	return x
+}
+
+// term retorna o nome da feature de um índice (termo ou característica de estilo)
+func (c *Classifier) term(index int) string {
+	if index >= c.Vectorizer.Size() {
+		return stylometry.Token(index - c.Vectorizer.Size())
+	}
+	return c.Vectorizer.Terms[index]
+}
+
+// scores retorna a pontuação linear w·x + b de cada classe
+func (c *Classifier) scores(x features.Vector) []float64 {
+	scores := make([]float64, len(c.Classes))
+	for k := range c.Classes {
+		scores[k] = x.Dot(c.Weights[k]) + c.Bias[k]
+	}
+	return scores
+}
+
+// Probabilities retorna as probabilidades de cada classe (implementa
+// models.ProbabilityModel). Na regressão logística elas vêm da sigmoide das
+// pontuações (normalizadas entre as classes); na SVM, as margens são convertidas
+// por softmax e não são probabilidades calibradas.
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	scores := c.scores(c.vectorize(text))
+
+	probs := make(map[string]float64, len(c.Classes))
+	if c.Loss == LossLogistic {
+		sum := 0.0
+		for k := range scores {
+			scores[k] = 1 / (1 + math.Exp(-scores[k]))
+			sum += scores[k]
+		}
+		for k, label := range c.Classes {
+			probs[label] = scores[k] / sum * 100
+		}
+	} else {
+		maxScore := math.Inf(-1)
+		for _, s := range scores {
+			maxScore = math.Max(maxScore, s)
+		}
+		sum := 0.0
+		for k := range scores {
+			scores[k] = math.Exp(scores[k] - maxScore)
+			sum += scores[k]
+		}
+		for k, label := range c.Classes {
+			probs[label] = scores[k] / sum * 100
+		}
+	}
+	return probs
+}
+
+// Predict classifica um texto com as probabilidades de Probabilities e as
+// contribuições dos termos
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	probs := c.Probabilities(text)
+	result := models.ClassificationResult{Probabilities: probs}
+	for _, label := range c.Classes {
+		if result.Label == "" || probs[label] > result.Confidence {
+			result.Label = label
+			result.Confidence = probs[label]
+		}
+	}
+
+	result.Contributions = c.Explain(text)
+	for _, contrib := range result.Contributions {
+		result.TopTokens = append(result.TopTokens, contrib.Token)
+		if len(result.TopTokens) >= 10 {
+			break
+		}
+	}
+	return result
+}
+
+// Explain retorna a contribuição de cada token para a pontuação de cada classe:
+// o valor da feature no vetor do texto multiplicado pelo peso da classe
+func (c *Classifier) Explain(text string) []models.TokenContribution {
+	counts := make(map[string]int)
+	for _, token := range utils.PreprocessText(text) {
+		counts[token]++
+	}
+
+	x := c.vectorize(text)
+	var contributions []models.TokenContribution
+	for k, index := range x.Indices {
+		term := c.term(index)
+		count := counts[term]
+		if index >= c.Vectorizer.Size() {
+			count = 1
+		}
+		contrib := models.TokenContribution{
+			Token:  term,
+			Count:  count,
+			Scores: make(map[string]float64, len(c.Classes)),
+		}
+		for j, label := range c.Classes {
+			contrib.Scores[label] = x.Values[k] * c.Weights[j][index]
+		}
+		contributions = append(contributions, contrib)
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Magnitude() > contributions[j].Magnitude()
+	})
+	return contributions
+}
@@ -0,0 +1,158 @@
+package linear
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/features"
+)
+
+// weightVector guarda os pesos como escala · v, o que permite aplicar o
+// decaimento L2 a todos os pesos em O(1) a cada passo
+type weightVector struct {
+	v      []float64
+	scale  float64
+	sqNorm float64 // ||v||², mantido incrementalmente
+}
+
+func newWeightVector(dim int) *weightVector {
+	return &weightVector{v: make([]float64, dim), scale: 1}
+}
+
+// dot calcula w·x
+func (w *weightVector) dot(x features.Vector) float64 {
+	return w.scale * x.Dot(w.v)
+}
+
+// shrink multiplica todos os pesos por factor
+func (w *weightVector) shrink(factor float64) {
+	if factor <= 0 {
+		for i := range w.v {
+			w.v[i] = 0
+		}
+		w.scale, w.sqNorm = 1, 0
+		return
+	}
+	w.scale *= factor
+	if w.scale < 1e-9 {
+		w.rescale()
+	}
+}
+
+// add soma step · x aos pesos
+func (w *weightVector) add(x features.Vector, step float64) {
+	for k, i := range x.Indices {
+		old := w.v[i]
+		w.v[i] += step * x.Values[k] / w.scale
+		w.sqNorm += w.v[i]*w.v[i] - old*old
+	}
+}
+
+// norm retorna ||w||
+func (w *weightVector) norm() float64 {
+	return w.scale * math.Sqrt(math.Max(w.sqNorm, 0))
+}
+
+// rescale incorpora a escala aos pesos
+func (w *weightVector) rescale() {
+	for i := range w.v {
+		w.v[i] *= w.scale
+	}
+	w.sqNorm *= w.scale * w.scale
+	w.scale = 1
+}
+
+// weights retorna os pesos finais
+func (w *weightVector) weights() []float64 {
+	w.rescale()
+	return w.v
+}
+
+// trainLogistic treina uma regressão logística binária (y = ±1) por gradiente
+// descendente estocástico com taxa η_t = η0 / (1 + η0·λ·t). A regularização L2
+// usa decaimento multiplicativo; a L1 usa a penalidade acumulada de Tsuruoka et al.
+// (aplicada apenas aos pesos tocados, que são truncados em zero). O viés não é regularizado.
+func trainLogistic(X []features.Vector, y []float64, dim int, opts Options) ([]float64, float64) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	w := newWeightVector(dim)
+	bias := 0.0
+
+	// Estado da penalidade L1 acumulada
+	applied := make([]float64, dim)
+	total := 0.0
+
+	t := 0
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		for _, d := range rng.Perm(len(X)) {
+			t++
+			lr := opts.Eta0 / (1 + opts.Eta0*opts.Lambda*float64(t))
+
+			target := 0.0
+			if y[d] > 0 {
+				target = 1
+			}
+			prob := 1 / (1 + math.Exp(-(w.dot(X[d]) + bias)))
+			gradient := target - prob
+
+			if opts.Penalty == PenaltyL2 {
+				w.shrink(1 - lr*opts.Lambda)
+			}
+			w.add(X[d], lr*gradient)
+			bias += lr * gradient
+
+			if opts.Penalty == PenaltyL1 {
+				total += lr * opts.Lambda
+				for _, i := range X[d].Indices {
+					z := w.v[i]
+					if z > 0 {
+						w.v[i] = math.Max(0, z-(total+applied[i]))
+					} else if z < 0 {
+						w.v[i] = math.Min(0, z+(total-applied[i]))
+					}
+					applied[i] += w.v[i] - z
+				}
+			}
+		}
+	}
+
+	return w.weights(), bias
+}
+
+// trainPegasos treina uma SVM linear binária (y = ±1) com o algoritmo Pegasos:
+// a cada passo, η_t = 1 / (λ·t), os pesos decaem por (1 − η_t·λ), recebem
+// η_t·y·x quando a margem é menor que 1 e são projetados na bola de raio 1/√λ.
+// O viés é tratado como uma feature constante (e, portanto, regularizado).
+func trainPegasos(X []features.Vector, y []float64, dim int, opts Options) ([]float64, float64) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	w := newWeightVector(dim + 1)
+	radius := 1 / math.Sqrt(opts.Lambda)
+
+	t := 0
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		for _, d := range rng.Perm(len(X)) {
+			t++
+			lr := 1 / (opts.Lambda * float64(t))
+			x := withBias(X[d], dim)
+			margin := y[d] * w.dot(x)
+
+			w.shrink(1 - lr*opts.Lambda)
+			if margin < 1 {
+				w.add(x, lr*y[d])
+			}
+			if norm := w.norm(); norm > radius {
+				w.shrink(radius / norm)
+			}
+		}
+	}
+
+	weights := w.weights()
+	return weights[:dim], weights[dim]
+}
+
+// withBias acrescenta a feature constante 1 no índice dim
+func withBias(x features.Vector, dim int) features.Vector {
+	return features.Vector{
+		Indices: append(x.Indices[:len(x.Indices):len(x.Indices)], dim),
+		Values:  append(x.Values[:len(x.Values):len(x.Values)], 1),
+	}
+}
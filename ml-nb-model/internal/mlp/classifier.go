@@ -1,13 +1,15 @@
 package mlp
 
 import (
-	"fmt"
 	"math"
 	"math/rand"
 	"sort"
-	"strings"
 
+	"github.com/souza/esw-008/ml-nb-model/internal/embeddings"
+	"github.com/souza/esw-008/ml-nb-model/internal/features"
 	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/selection"
+	"github.com/souza/esw-008/ml-nb-model/internal/stylometry"
 	"github.com/souza/esw-008/ml-nb-model/internal/utils"
 )
 
@@ -24,28 +26,56 @@ type Layer struct {
 	Neurons []*Neuron
 }
 
+// Modos de entrada da rede
+const (
+	InputBagOfWords = "bow"       // presença das palavras mais frequentes (InputSize = vocabulário)
+	InputEmbedding  = "embedding" // embedding denso do documento (InputSize = dimensão dos vetores)
+	InputHashing    = "hashing"   // presença dos termos por hashing, sem vocabulário (InputSize = Hashing.Dim)
+)
+
 // Classifier representa o classificador MLP
 type Classifier struct {
 	Layers       []*Layer
 	Vocab        map[string]int
 	StopWords    map[string]bool
 	InputSize    int
+	VocabSize    int // tamanho máximo do vocabulário na entrada bag-of-words
 	HiddenSize   int
 	OutputSize   int
 	LearningRate float64
 	Epochs       int
+	Labels       []string // rótulo de cada neurônio de saída (definidos no treinamento)
+
+	Input      string              // InputBagOfWords, InputEmbedding ou InputHashing
+	Embedding  embeddings.Options  // treinamento dos vetores no modo embedding (sem Pretrained)
+	Weighting  string              // ponderação das palavras no embedding do documento
+	Pretrained *embeddings.Vectors // vetores pré-treinados (nil = treinar nos dados de treinamento)
+	Embedder   *embeddings.Embedder
+
+	Stylometry bool               // acrescenta as características de estilo à entrada
+	Style      *stylometry.Scaler // padronização das características de estilo (nil sem Stylometry)
+
+	Selection selection.Options     // seleção do vocabulário bag-of-words (tamanho = VocabSize)
+	Selected  *selection.Vocabulary // vocabulário selecionado no treinamento
+
+	Hashing *features.HashingVectorizer // espaço de hashing no modo InputHashing (nil = VocabSize dimensões)
 }
 
 // NewClassifier cria um novo classificador MLP
 func NewClassifier(inputSize, hiddenSize, outputSize int) *Classifier {
 	classifier := &Classifier{
 		InputSize:    inputSize,
+		VocabSize:    inputSize,
 		HiddenSize:   hiddenSize,
 		OutputSize:   outputSize,
 		LearningRate: 0.01,
 		Epochs:       100,
 		Vocab:        make(map[string]int),
 		StopWords:    utils.GetStopWords(),
+		Input:        InputBagOfWords,
+		Embedding:    embeddings.DefaultOptions(),
+		Weighting:    embeddings.WeightingTFIDF,
+		Selection:    selection.DefaultOptions(),
 	}
 
 	classifier.initializeLayers()
@@ -85,57 +115,66 @@ func (c *Classifier) initializeLayers() {
 	c.Layers = []*Layer{hiddenLayer, outputLayer}
 }
 
-// buildVocabulary constrói o vocabulário a partir dos dados de treinamento
-func (c *Classifier) buildVocabulary(records []models.NewsRecord) {
-	wordCounts := make(map[string]int)
-
-	// Contar frequência de palavras
-	for _, record := range records {
-		// Processar texto falso
-		if strings.TrimSpace(record.FakeText) != "" {
-			tokens := utils.PreprocessText(record.FakeText)
-			for _, token := range tokens {
-				wordCounts[token]++
-			}
-		}
+// buildVocabulary constrói o vocabulário a partir dos dados de treinamento: os
+// VocabSize termos mais bem ranqueados pela seleção (por padrão, os mais frequentes)
+func (c *Classifier) buildVocabulary(docs []models.Document) {
+	texts, labels := models.Samples(docs)
+	opts := c.Selection
+	opts.Size = c.VocabSize
+	vocab, err := selection.Select(texts, labels, opts)
+	if err != nil {
+		// Opções inválidas (rejeitadas pela configuração de hiperparâmetros): usar a frequência
+		vocab, _ = selection.Select(texts, labels, selection.Options{Method: selection.MethodFrequency, MinDF: 1, MaxDF: 1, Size: c.VocabSize})
+	}
+	c.Selected = vocab
+	c.Vocab = make(map[string]int, len(vocab.Terms))
+	for i, word := range vocab.Words() {
+		c.Vocab[word] = i
+	}
+}
 
-		// Processar texto verdadeiro
-		if strings.TrimSpace(record.TrueText) != "" {
-			tokens := utils.PreprocessText(record.TrueText)
-			for _, token := range tokens {
-				wordCounts[token]++
-			}
+// buildEmbedder prepara o embedding de documentos: usa os vetores pré-treinados
+// ou treina vetores nos textos de treinamento, e calcula o idf das palavras
+func (c *Classifier) buildEmbedder(texts []string) error {
+	vectors := c.Pretrained
+	if vectors == nil {
+		models.Logf("Treinando embeddings %s (%d dimensões) com %d documentos...\n", c.Embedding.Method, c.Embedding.Dim, len(texts))
+		trained, err := embeddings.Train(texts, c.Embedding)
+		if err != nil {
+			return err
 		}
+		vectors = trained
 	}
 
-	// Converter para slice para ordenação
-	type wordCount struct {
-		word  string
-		count int
+	embedder, err := embeddings.NewEmbedder(vectors, c.Weighting)
+	if err != nil {
+		return err
 	}
+	embedder.Fit(texts)
+	c.Embedder = embedder
+	return nil
+}
 
-	var wordCountList []wordCount
-	for word, count := range wordCounts {
-		wordCountList = append(wordCountList, wordCount{word, count})
+// textToVector converte texto para vetor de entrada, seguido das
+// características de estilo quando Stylometry está ativo
+func (c *Classifier) textToVector(text string) []float64 {
+	var vector []float64
+	if c.Embedder != nil {
+		vector = c.Embedder.Embed(text)
+	} else if c.Input == InputHashing {
+		vector = c.hashedBag(text)
+	} else {
+		vector = c.bagOfWords(text)
 	}
-
-	// Ordenar por frequência (decrescente)
-	sort.Slice(wordCountList, func(i, j int) bool {
-		return wordCountList[i].count > wordCountList[j].count
-	})
-
-	// Pegar as 1000 palavras mais frequentes
-	for i, wc := range wordCountList {
-		if i >= c.InputSize {
-			break
-		}
-		c.Vocab[wc.word] = i
+	if c.Style != nil {
+		vector = append(vector, c.Style.Transform(text)...)
 	}
+	return vector
 }
 
-// textToVector converte texto para vetor de entrada
-func (c *Classifier) textToVector(text string) []float64 {
-	vector := make([]float64, c.InputSize)
+// bagOfWords marca a presença das palavras do vocabulário no texto
+func (c *Classifier) bagOfWords(text string) []float64 {
+	vector := make([]float64, c.baseSize())
 	tokens := utils.PreprocessText(text)
 
 	for _, token := range tokens {
@@ -147,6 +186,22 @@ func (c *Classifier) textToVector(text string) []float64 {
 	return vector
 }
 
+// hashedBag marca a presença de cada termo no seu índice de hashing, com o sinal
+// do termo; termos que colidem somam seus sinais
+func (c *Classifier) hashedBag(text string) []float64 {
+	vector := make([]float64, c.baseSize())
+	seen := make(map[string]bool)
+	for _, term := range c.Hashing.Terms(text) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		index, sign := c.Hashing.Bucket(term)
+		vector[index] += sign
+	}
+	return vector
+}
+
 // sigmoid função de ativação sigmoid
 func sigmoid(x float64) float64 {
 	return 1.0 / (1.0 + math.Exp(-x))
@@ -228,37 +283,74 @@ func (c *Classifier) backwardPropagation(input []float64, target []float64) {
 	}
 }
 
+// baseSize retorna o tamanho da entrada sem as características de estilo:
+// a dimensão dos embeddings ou o tamanho máximo do vocabulário
+func (c *Classifier) baseSize() int {
+	if c.Embedder != nil {
+		return c.Embedder.Dim()
+	}
+	if c.Input == InputHashing {
+		return c.Hashing.Size()
+	}
+	return c.VocabSize
+}
+
 // Train treina o classificador
-func (c *Classifier) Train(records []models.NewsRecord) {
-	// Construir vocabulário
-	c.buildVocabulary(records)
+func (c *Classifier) Train(docs []models.Document) {
+	texts, labels := models.Samples(docs)
+
+	// Construir vocabulário ou embeddings
+	c.Embedder = nil
+	if c.Input == InputEmbedding {
+		if err := c.buildEmbedder(texts); err != nil {
+			models.Logf("Erro nos embeddings (%v); usando bag-of-words\n", err)
+			c.Embedder = nil
+		}
+	}
+	if c.Input == InputHashing {
+		if c.Hashing == nil {
+			c.Hashing = features.NewHashingVectorizer(c.VocabSize)
+		}
+		c.Vocab = make(map[string]int)
+		c.Selected = nil
+	} else if c.Embedder == nil {
+		c.buildVocabulary(docs)
+	}
+	inputSize := c.baseSize()
+
+	// Características de estilo depois do vocabulário/embedding
+	c.Style = nil
+	if c.Stylometry {
+		c.Style = &stylometry.Scaler{}
+		c.Style.Fit(texts)
+		inputSize += len(stylometry.Features)
+	}
 
-	// Preparar dados de treinamento
+	// Uma saída por rótulo presente nos dados
+	c.Labels = models.SortedLabels(labels)
+	if len(c.Labels) != c.OutputSize || inputSize != c.InputSize {
+		c.OutputSize = len(c.Labels)
+		c.InputSize = inputSize
+		c.initializeLayers()
+	}
+	index := make(map[string]int, len(c.Labels))
+	for i, label := range c.Labels {
+		index[label] = i
+	}
+
+	// Preparar dados de treinamento (alvo one-hot do rótulo)
 	var trainingData []struct {
 		input  []float64
 		target []float64
 	}
 
-	for _, record := range records {
-		// Adicionar texto falso
-		if strings.TrimSpace(record.FakeText) != "" {
-			input := c.textToVector(record.FakeText)
-			target := []float64{0.0, 1.0} // [verdadeira, falsa]
-			trainingData = append(trainingData, struct {
-				input  []float64
-				target []float64
-			}{input, target})
-		}
-
-		// Adicionar texto verdadeiro
-		if strings.TrimSpace(record.TrueText) != "" {
-			input := c.textToVector(record.TrueText)
-			target := []float64{1.0, 0.0} // [verdadeira, falsa]
-			trainingData = append(trainingData, struct {
-				input  []float64
-				target []float64
-			}{input, target})
-		}
+	for i, text := range texts {
+		target := make([]float64, c.OutputSize)
+		target[index[labels[i]]] = 1.0
+		trainingData = append(trainingData, struct {
+			input  []float64
+			target []float64
+		}{c.textToVector(text), target})
 	}
 
 	// Treinamento
@@ -281,63 +373,275 @@ func (c *Classifier) Train(records []models.NewsRecord) {
 
 		// Imprimir progresso a cada 10 épocas
 		if epoch%10 == 0 {
-			fmt.Printf("Época %d/%d, Erro: %f\n", epoch, c.Epochs, totalError)
+			models.Logf("Época %d/%d, Erro: %f\n", epoch, c.Epochs, totalError)
 		}
 	}
 
-	fmt.Println("Treinamento concluído!")
+	models.Logf("Treinamento concluído!\n")
 }
 
 // Classify classifica um texto
 func (c *Classifier) Classify(text string) (string, float64) {
-	input := c.textToVector(text)
-	outputs := c.forwardPropagation(input)
+	label, confidence, _ := c.classify(text)
+	return label, confidence
+}
 
-	// Determinar classe
-	if outputs[0] > outputs[1] {
-		return "true", outputs[0] * 100
-	} else {
-		return "fake", outputs[1] * 100
+// classify retorna a classe de maior saída, sua probabilidade e as probabilidades de todas as classes
+func (c *Classifier) classify(text string) (string, float64, map[string]float64) {
+	outputs := c.forwardPropagation(c.textToVector(text))
+	probs := c.normalizeOutputs(outputs)
+
+	best := 0
+	for i, output := range outputs {
+		if output > outputs[best] {
+			best = i
+		}
+	}
+	if best >= len(c.Labels) {
+		return "", 0, probs
+	}
+	return c.Labels[best], probs[c.Labels[best]], probs
+}
+
+// normalizeOutputs converte as saídas sigmoides independentes em probabilidades
+// (0-100) que somam 100, dividindo cada saída pela soma de todas
+func (c *Classifier) normalizeOutputs(outputs []float64) map[string]float64 {
+	probs := make(map[string]float64, len(c.Labels))
+	sum := 0.0
+	for i := range c.Labels {
+		sum += outputs[i]
+	}
+	for i, label := range c.Labels {
+		if sum <= 0 {
+			probs[label] = 100 / float64(len(c.Labels))
+			continue
+		}
+		probs[label] = outputs[i] / sum * 100
 	}
+	return probs
+}
+
+// Probabilities retorna as probabilidades de cada classe, sem as contribuições
+// dos tokens (implementa models.ProbabilityModel)
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	_, _, probs := c.classify(text)
+	return probs
 }
 
 // ClassifyWithDebug classifica um texto com informações detalhadas
 func (c *Classifier) ClassifyWithDebug(text string) (string, float64, map[string]float64, []string) {
+	result := c.Predict(text)
+	return result.Label, result.Confidence, result.Probabilities, result.TopTokens
+}
+
+// Predict classifica um texto e retorna o resultado completo (implementa models.Classifier)
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	label, confidence, probs := c.classify(text)
+	result := models.ClassificationResult{
+		Label:         label,
+		Confidence:    confidence,
+		Probabilities: probs,
+	}
+
+	// Tokens mais influentes, das mesmas contribuições (calculadas uma única vez)
+	result.Contributions = c.Explain(text)
+	for _, contrib := range result.Contributions {
+		result.TopTokens = append(result.TopTokens, contrib.Token)
+		if len(result.TopTokens) >= 10 {
+			break
+		}
+	}
+	return result
+}
+
+// Explain calcula a contribuição assinada de cada token do texto para cada classe
+// usando a atribuição gradiente × entrada: para cada feature ativa x_i, a contribuição
+// para a saída c é x_i · ∂o_c/∂x_i, obtida propagando o gradiente da saída até a entrada.
+func (c *Classifier) Explain(text string) []models.TokenContribution {
+	if c.Embedder != nil {
+		return c.explainEmbedding(text)
+	}
+
+	if c.Input == InputHashing {
+		return c.explainHashing(text)
+	}
+
 	input := c.textToVector(text)
-	outputs := c.forwardPropagation(input)
+	c.forwardPropagation(input)
+
+	// Contar ocorrências dos tokens presentes no vocabulário
+	counts := make(map[string]int)
+	var order []string
+	for _, token := range utils.PreprocessText(text) {
+		if _, exists := c.Vocab[token]; !exists {
+			continue
+		}
+		if counts[token] == 0 {
+			order = append(order, token)
+		}
+		counts[token]++
+	}
 
-	// Calcular probabilidades
-	probs := map[string]float64{
-		"true": outputs[0] * 100,
-		"fake": outputs[1] * 100,
+	var contributions []models.TokenContribution
+	for _, token := range order {
+		contributions = append(contributions, models.TokenContribution{
+			Token:  token,
+			Count:  counts[token],
+			Scores: c.attribution(input, c.Vocab[token]),
+		})
 	}
+	contributions = append(contributions, c.styleContributions(input)...)
 
-	// Determinar classe e confiança
-	var label string
-	var confidence float64
-	if outputs[0] > outputs[1] {
-		label = "true"
-		confidence = outputs[0] * 100
-	} else {
-		label = "fake"
-		confidence = outputs[1] * 100
+	// Ordenar por contribuição (mais influentes primeiro)
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Magnitude() > contributions[j].Magnitude()
+	})
+
+	return contributions
+}
+
+// explainHashing calcula a contribuição dos termos no modo hashing: a atribuição
+// do índice é dividida entre os termos que colidem nele, proporcionalmente ao sinal
+// de cada um (sign / x_i), de modo que termos nunca vistos também são explicados
+func (c *Classifier) explainHashing(text string) []models.TokenContribution {
+	input := c.textToVector(text)
+	c.forwardPropagation(input)
+
+	counts := make(map[string]int)
+	var order []string
+	for _, term := range c.Hashing.Terms(text) {
+		if counts[term] == 0 {
+			order = append(order, term)
+		}
+		counts[term]++
 	}
 
-	// Encontrar tokens mais influentes
-	tokens := utils.PreprocessText(text)
-	var topTokens []string
-	for _, token := range tokens {
-		if index, exists := c.Vocab[token]; exists && index < len(input) {
-			if input[index] > 0 {
-				topTokens = append(topTokens, token)
+	var contributions []models.TokenContribution
+	for _, term := range order {
+		index, sign := c.Hashing.Bucket(term)
+		scores := c.attribution(input, index)
+		for label, score := range scores {
+			if input[index] == 0 {
+				scores[label] = 0
+			} else {
+				scores[label] = score * sign / input[index]
 			}
 		}
+		contributions = append(contributions, models.TokenContribution{
+			Token:  term,
+			Count:  counts[term],
+			Scores: scores,
+		})
 	}
+	contributions = append(contributions, c.styleContributions(input)...)
+
+	// Ordenar por contribuição (mais influentes primeiro)
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Magnitude() > contributions[j].Magnitude()
+	})
+
+	return contributions
+}
+
+// attribution calcula a atribuição gradiente × entrada da feature index para
+// cada classe, x_i · ∂o_c/∂x_i, com as saídas da última propagação para frente:
+// ∂o_k/∂x_i = o_k(1-o_k) · Σ_j w_kj · h_j(1-h_j) · W_ji
+func (c *Classifier) attribution(input []float64, index int) map[string]float64 {
+	hidden := c.Layers[0].Neurons
+	output := c.Layers[len(c.Layers)-1].Neurons
 
-	// Limitar a 10 tokens
-	if len(topTokens) > 10 {
-		topTokens = topTokens[:10]
+	scores := make(map[string]float64, len(c.Labels))
+	for k, out := range output {
+		if k >= len(c.Labels) {
+			break
+		}
+		grad := 0.0
+		for j, h := range hidden {
+			grad += out.Weights[j] * sigmoidDerivative(h.Output) * h.Weights[index]
+		}
+		grad *= sigmoidDerivative(out.Output)
+		scores[c.Labels[k]] = grad * input[index]
+	}
+	return scores
+}
+
+// styleContributions retorna a contribuição de cada característica de estilo
+// (nenhuma sem Stylometry), com as saídas da última propagação para frente
+func (c *Classifier) styleContributions(input []float64) []models.TokenContribution {
+	if c.Style == nil {
+		return nil
+	}
+	offset := c.baseSize()
+	contributions := make([]models.TokenContribution, len(stylometry.Features))
+	for i := range contributions {
+		contributions[i] = models.TokenContribution{
+			Token:  stylometry.Token(i),
+			Count:  1,
+			Scores: c.attribution(input, offset+i),
+		}
+	}
+	return contributions
+}
+
+// explainEmbedding calcula a contribuição dos tokens no modo embedding: o embedding
+// do documento é a soma das parcelas de cada palavra, e a contribuição de uma
+// palavra para a saída c é o gradiente ∂o_c/∂x aplicado à sua parcela
+func (c *Classifier) explainEmbedding(text string) []models.TokenContribution {
+	components := c.Embedder.Components(text)
+	input := c.textToVector(text)
+	c.forwardPropagation(input)
+
+	hidden := c.Layers[0].Neurons
+	output := c.Layers[len(c.Layers)-1].Neurons
+
+	// Gradiente de cada saída em relação à entrada
+	gradients := make([][]float64, len(c.Labels))
+	for k := range gradients {
+		if k >= len(output) {
+			break
+		}
+		gradients[k] = make([]float64, c.InputSize)
+		for j, h := range hidden {
+			factor := output[k].Weights[j] * sigmoidDerivative(h.Output) * sigmoidDerivative(output[k].Output)
+			for d, w := range h.Weights {
+				gradients[k][d] += factor * w
+			}
+		}
+	}
+
+	counts := make(map[string]int)
+	var order []string
+	for _, token := range utils.PreprocessText(text) {
+		if _, exists := components[token]; !exists {
+			continue
+		}
+		if counts[token] == 0 {
+			order = append(order, token)
+		}
+		counts[token]++
 	}
 
-	return label, confidence, probs, topTokens
+	var contributions []models.TokenContribution
+	for _, token := range order {
+		scores := make(map[string]float64, len(c.Labels))
+		for k, label := range c.Labels {
+			if gradients[k] == nil {
+				continue
+			}
+			for d, value := range components[token] {
+				scores[label] += gradients[k][d] * value
+			}
+		}
+		contributions = append(contributions, models.TokenContribution{
+			Token:  token,
+			Count:  counts[token],
+			Scores: scores,
+		})
+	}
+	contributions = append(contributions, c.styleContributions(input)...)
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Magnitude() > contributions[j].Magnitude()
+	})
+	return contributions
 }
@@ -0,0 +1,15 @@
+package models
+
+import "fmt"
+
+// Verbose controla as mensagens de progresso impressas pelos classificadores durante
+// o treinamento. Fica desligado, por exemplo, na busca de hiperparâmetros, que treina
+// centenas de modelos em paralelo; deve ser alterado apenas fora dos treinamentos.
+var Verbose = true
+
+// Logf imprime uma mensagem de progresso do treinamento quando Verbose está ativo
+func Logf(format string, args ...interface{}) {
+	if Verbose {
+		fmt.Printf(format, args...)
+	}
+}
@@ -1,6 +1,12 @@
 package models
 
-// NewsRecord representa um par de notícias (falsa e verdadeira)
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// NewsRecord representa um par de notícias (falsa e verdadeira) do FakeTrue.Br
 type NewsRecord struct {
 	TitleFake string
 	FakeText  string
@@ -9,18 +15,76 @@ type NewsRecord struct {
 	LinkTrue  string
 }
 
+// Document representa um documento rotulado, independente do formato do corpus
+// (os adaptadores de internal/dataset convertem cada formato para Document)
+type Document struct {
+	ID       string            `json:"id"`
+	Text     string            `json:"text"`
+	Title    string            `json:"title,omitempty"`
+	URL      string            `json:"url,omitempty"`
+	Label    string            `json:"label"`
+	Source   string            `json:"source,omitempty"`
+	Date     string            `json:"date,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GroupKey é a chave de Metadata que agrupa documentos relacionados
+const GroupKey = "group"
+
+// Group retorna o grupo do documento: documentos do mesmo grupo (ex.: os dois
+// lados de um par do FakeTrue.Br) ficam sempre no mesmo fold. Sem grupo
+// definido em Metadata, cada documento é o seu próprio grupo.
+func (d Document) Group() string {
+	if group := d.Metadata[GroupKey]; group != "" {
+		return group
+	}
+	return d.ID
+}
+
 // Metrics representa as métricas de avaliação
 type Metrics struct {
 	Accuracy  float64
 	Precision float64
 	Recall    float64
 	F1Score   float64
+	ECE       float64 // erro de calibração esperado (0 quando não calculado)
+
+	Classes   []string                  // rótulos presentes nas predições ou nos rótulos reais
+	PerClass  map[string]ClassMetrics   // métricas de cada classe contra as demais
+	Confusion map[string]map[string]int // [real][previsto]
+}
+
+// ClassMetrics representa as métricas de uma classe (um contra todos)
+type ClassMetrics struct {
+	Precision float64
+	Recall    float64
+	F1Score   float64
+	Support   int // documentos da classe
 }
 
 // Fold representa um fold para cross-validation
 type Fold struct {
-	Train []NewsRecord
-	Test  []NewsRecord
+	Train []Document
+	Test  []Document
+}
+
+// TokenContribution representa a contribuição assinada de um token (ou feature)
+// para cada classe: valores positivos favorecem a classe, negativos a desfavorecem
+type TokenContribution struct {
+	Token  string
+	Count  int
+	Scores map[string]float64
+}
+
+// Magnitude retorna a maior contribuição absoluta do token entre as classes
+func (t TokenContribution) Magnitude() float64 {
+	magnitude := 0.0
+	for _, score := range t.Scores {
+		if math.Abs(score) > magnitude {
+			magnitude = math.Abs(score)
+		}
+	}
+	return magnitude
 }
 
 // ClassificationResult representa o resultado de uma classificação
@@ -28,5 +92,65 @@ type ClassificationResult struct {
 	Label         string
 	Confidence    float64
 	Probabilities map[string]float64
-	TopTokens     []string
+	// RawProbabilities guarda as probabilidades antes da calibração (nil se não calibrado)
+	RawProbabilities map[string]float64
+	TopTokens        []string
+	Contributions    []TokenContribution // ordenadas por influência (maior |score| primeiro)
+}
+
+// Classifier é a interface comum aos classificadores de notícias
+type Classifier interface {
+	Train(docs []Document)
+	Predict(text string) ClassificationResult
+}
+
+// ProbabilityModel é implementado pelos classificadores que calculam só as
+// probabilidades de um texto, sem as contribuições dos tokens; é bem mais barato
+// que Predict quando o mesmo modelo classifica muitos textos (ex.: as perturbações do LIME)
+type ProbabilityModel interface {
+	Probabilities(text string) map[string]float64
+}
+
+// Probabilities retorna as probabilidades (0-100) de cada classe para o texto,
+// sem calcular as contribuições quando o classificador implementa ProbabilityModel
+func Probabilities(classifier Classifier, text string) map[string]float64 {
+	if model, ok := classifier.(ProbabilityModel); ok {
+		return model.Probabilities(text)
+	}
+	return classifier.Predict(text).Probabilities
+}
+
+// Factory cria um classificador novo (não treinado), usado em cada fold de cross-validation
+type Factory func() Classifier
+
+// Samples retorna os textos e rótulos dos documentos (ignorando textos vazios)
+func Samples(docs []Document) ([]string, []string) {
+	var texts, labels []string
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.Text) != "" && doc.Label != "" {
+			texts = append(texts, doc.Text)
+			labels = append(labels, doc.Label)
+		}
+	}
+	return texts, labels
+}
+
+// Labels retorna os rótulos distintos dos documentos, em ordem alfabética
+func Labels(docs []Document) []string {
+	_, labels := Samples(docs)
+	return SortedLabels(labels)
+}
+
+// SortedLabels retorna os rótulos distintos, em ordem alfabética
+func SortedLabels(labels []string) []string {
+	seen := make(map[string]bool)
+	var distinct []string
+	for _, label := range labels {
+		if !seen[label] {
+			seen[label] = true
+			distinct = append(distinct, label)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
 }
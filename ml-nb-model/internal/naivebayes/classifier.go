@@ -1,21 +1,33 @@
 package naivebayes
 
 import (
-	"fmt"
 	"math"
 	"sort"
-	"strings"
 
+	"github.com/souza/esw-008/ml-nb-model/internal/features"
 	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/selection"
 	"github.com/souza/esw-008/ml-nb-model/internal/utils"
 )
 
 // Classifier representa o classificador Naive Bayes
 type Classifier struct {
 	WordCounts  map[string]map[string]int
-	ClassCounts map[string]int
+	ClassCounts map[string]int // documentos por classe (prior)
+	TokenCounts map[string]int // tokens por classe (denominador de P(token | classe))
 	Vocab       map[string]bool
 	StopWords   map[string]bool
+	Classes     []string // rótulos vistos no treinamento, em ordem alfabética
+	Alpha       float64  // suavização de Lidstone (1 = Laplace)
+
+	// Seleção do vocabulário; com vocabulário podado, tokens fora dele são ignorados
+	Selection selection.Options
+	Selected  *selection.Vocabulary
+
+	// Hashing substitui o vocabulário por um espaço de dimensão fixa (nil = vocabulário):
+	// as contagens ficam em HashCounts, indexadas pelo hash do termo
+	Hashing    *features.HashingVectorizer
+	HashCounts map[string][]int
 }
 
 // NewClassifier cria um novo classificador Naive Bayes
@@ -23,168 +35,231 @@ func NewClassifier() *Classifier {
 	return &Classifier{
 		WordCounts:  make(map[string]map[string]int),
 		ClassCounts: make(map[string]int),
+		TokenCounts: make(map[string]int),
 		Vocab:       make(map[string]bool),
 		StopWords:   utils.GetStopWords(),
+		Alpha:       1,
+		Selection:   selection.DefaultOptions(),
 	}
 }
 
-// buildVocabularyNB constrói o vocabulário para Naive Bayes
-func (c *Classifier) buildVocabularyNB(records []models.NewsRecord) {
-	for _, record := range records {
-		// Processar texto falso
-		if strings.TrimSpace(record.FakeText) != "" {
-			tokens := utils.PreprocessText(record.FakeText)
-			for _, token := range tokens {
-				c.Vocab[token] = true
-			}
-		}
+// buildVocabularyNB constrói o vocabulário para Naive Bayes (por padrão, todos os tokens)
+func (c *Classifier) buildVocabularyNB(docs []models.Document) {
+	texts, labels := models.Samples(docs)
+	vocab, err := selection.Select(texts, labels, c.Selection)
+	if err != nil {
+		// Opções inválidas (rejeitadas pela configuração de hiperparâmetros): manter todos os tokens
+		vocab, _ = selection.Select(texts, labels, selection.DefaultOptions())
+	}
+	c.Selected = vocab
+	for _, word := range vocab.Words() {
+		c.Vocab[word] = true
+	}
+}
 
-		// Processar texto verdadeiro
-		if strings.TrimSpace(record.TrueText) != "" {
-			tokens := utils.PreprocessText(record.TrueText)
-			for _, token := range tokens {
-				c.Vocab[token] = true
-			}
+// tokens retorna os tokens do texto, sem os que ficaram fora de um vocabulário podado
+// (com Hashing, os termos do vetorizador, incluindo os n-gramas)
+func (c *Classifier) tokens(text string) []string {
+	if c.Hashing != nil {
+		return c.Hashing.Terms(text)
+	}
+	tokens := utils.PreprocessText(text)
+	if c.Selected == nil || !c.Selected.Pruned() {
+		return tokens
+	}
+	kept := tokens[:0]
+	for _, token := range tokens {
+		if c.Vocab[token] {
+			kept = append(kept, token)
 		}
 	}
+	return kept
 }
 
-// TrainNB treina o classificador Naive Bayes
-func (c *Classifier) TrainNB(records []models.NewsRecord) {
-	// Construir vocabulário
-	c.buildVocabularyNB(records)
-
-	// Inicializar contadores
-	c.WordCounts["true"] = make(map[string]int)
-	c.WordCounts["fake"] = make(map[string]int)
-	c.ClassCounts["true"] = 0
-	c.ClassCounts["fake"] = 0
+// TrainNB treina o classificador Naive Bayes com as classes presentes nos documentos
+func (c *Classifier) TrainNB(docs []models.Document) {
+	// Construir vocabulário (dispensado com hashing)
+	if c.Hashing == nil {
+		c.buildVocabularyNB(docs)
+	} else {
+		c.HashCounts = make(map[string][]int)
+	}
 
 	// Contar palavras por classe
-	for _, record := range records {
-		// Processar texto falso
-		if strings.TrimSpace(record.FakeText) != "" {
-			c.ClassCounts["fake"]++
-			tokens := utils.PreprocessText(record.FakeText)
-			for _, token := range tokens {
-				c.WordCounts["fake"][token]++
+	texts, labels := models.Samples(docs)
+	for i, text := range texts {
+		label := labels[i]
+		c.ClassCounts[label]++
+		if c.Hashing != nil {
+			if c.HashCounts[label] == nil {
+				c.HashCounts[label] = make([]int, c.Hashing.Size())
 			}
-		}
-
-		// Processar texto verdadeiro
-		if strings.TrimSpace(record.TrueText) != "" {
-			c.ClassCounts["true"]++
-			tokens := utils.PreprocessText(record.TrueText)
-			for _, token := range tokens {
-				c.WordCounts["true"][token]++
+			for _, term := range c.tokens(text) {
+				index, _ := c.Hashing.Bucket(term)
+				c.HashCounts[label][index]++
+				c.TokenCounts[label]++
 			}
+			continue
+		}
+		if c.WordCounts[label] == nil {
+			c.WordCounts[label] = make(map[string]int)
+		}
+		for _, token := range c.tokens(text) {
+			c.WordCounts[label][token]++
+			c.TokenCounts[label]++
 		}
 	}
+	c.Classes = models.SortedLabels(labels)
 
-	fmt.Println("Treinamento Naive Bayes concluído!")
+	models.Logf("Treinamento Naive Bayes concluído!\n")
 }
 
-// ClassifyNB classifica um texto usando Naive Bayes
-func (c *Classifier) ClassifyNB(text string) (string, float64) {
-	tokens := utils.PreprocessText(text)
-
-	// Calcular log-probabilidades
-	logProbTrue := math.Log(float64(c.ClassCounts["true"]))
-	logProbFake := math.Log(float64(c.ClassCounts["fake"]))
-
-	// Adicionar suavização de Laplace (α = 1)
-	alpha := 1.0
-	vocabSize := float64(len(c.Vocab))
+// logPosteriors calcula log P(classe) + Σ log P(token | classe) para cada classe
+func (c *Classifier) logPosteriors(tokens []string) []float64 {
+	scores := make([]float64, len(c.Classes))
+	for i, class := range c.Classes {
+		scores[i] = math.Log(float64(c.ClassCounts[class]))
+		for _, token := range tokens {
+			scores[i] += c.tokenLogProb(token, class)
+		}
+	}
+	return scores
+}
 
-	for _, token := range tokens {
-		// Probabilidade para classe "true"
-		countTrue := float64(c.WordCounts["true"][token])
-		logProbTrue += math.Log((countTrue + alpha) / (float64(c.ClassCounts["true"]) + alpha*vocabSize))
+// posterior converte as log-probabilidades em probabilidades (0-100) e retorna
+// a classe mais provável (empates ficam com a primeira em ordem alfabética)
+func (c *Classifier) posterior(text string) (string, float64, map[string]float64) {
+	scores := c.logPosteriors(c.tokens(text))
+	if len(scores) == 0 {
+		return "", 0, map[string]float64{}
+	}
 
-		// Probabilidade para classe "fake"
-		countFake := float64(c.WordCounts["fake"][token])
-		logProbFake += math.Log((countFake + alpha) / (float64(c.ClassCounts["fake"]) + alpha*vocabSize))
+	best := 0
+	for i, score := range scores {
+		if score > scores[best] {
+			best = i
+		}
 	}
 
-	// Determinar classe
-	if logProbTrue > logProbFake {
-		// Converter log-probabilidade para probabilidade
-		prob := 1.0 / (1.0 + math.Exp(logProbFake-logProbTrue))
-		return "true", prob * 100
-	} else {
-		prob := 1.0 / (1.0 + math.Exp(logProbTrue-logProbFake))
-		return "fake", prob * 100
+	// Softmax estável: subtrair o maior log antes da exponencial
+	sum := 0.0
+	for _, score := range scores {
+		sum += math.Exp(score - scores[best])
+	}
+	probs := make(map[string]float64, len(scores))
+	for i, score := range scores {
+		probs[c.Classes[i]] = math.Exp(score-scores[best]) / sum * 100
 	}
+
+	label := c.Classes[best]
+	return label, probs[label], probs
+}
+
+// ClassifyNB classifica um texto usando Naive Bayes
+func (c *Classifier) ClassifyNB(text string) (string, float64) {
+	label, confidence, _ := c.posterior(text)
+	return label, confidence
+}
+
+// Probabilities retorna as probabilidades de cada classe, sem as contribuições
+// dos tokens (implementa models.ProbabilityModel)
+func (c *Classifier) Probabilities(text string) map[string]float64 {
+	_, _, probs := c.posterior(text)
+	return probs
 }
 
 // ClassifyWithDebugNB classifica um texto com informações detalhadas
 func (c *Classifier) ClassifyWithDebugNB(text string) (string, float64, map[string]float64, []string) {
-	tokens := utils.PreprocessText(text)
-
-	// Calcular log-probabilidades
-	logProbTrue := math.Log(float64(c.ClassCounts["true"]))
-	logProbFake := math.Log(float64(c.ClassCounts["fake"]))
+	result := c.Predict(text)
+	return result.Label, result.Confidence, result.Probabilities, result.TopTokens
+}
 
-	// Adicionar suavização de Laplace (α = 1)
-	alpha := 1.0
+// tokenLogProb calcula log P(token | classe) com suavização aditiva (α = c.Alpha) sobre
+// o total de tokens da classe; com Hashing, a contagem é a do índice do token e o
+// vocabulário tem Dim termos
+func (c *Classifier) tokenLogProb(token string, class string) float64 {
+	alpha := c.Alpha
 	vocabSize := float64(len(c.Vocab))
-
-	// Calcular contribuição de cada token
-	type tokenContribution struct {
-		token string
-		score float64
+	count := float64(c.WordCounts[class][token])
+	if c.Hashing != nil {
+		index, _ := c.Hashing.Bucket(token)
+		vocabSize = float64(c.Hashing.Size())
+		count = 0
+		if counts := c.HashCounts[class]; counts != nil {
+			count = float64(counts[index])
+		}
 	}
-	var contributions []tokenContribution
+	return math.Log((count + alpha) / (float64(c.TokenCounts[class]) + alpha*vocabSize))
+}
 
-	for _, token := range tokens {
-		// Probabilidade para classe "true"
-		countTrue := float64(c.WordCounts["true"][token])
-		probTrue := math.Log((countTrue + alpha) / (float64(c.ClassCounts["true"]) + alpha*vocabSize))
-		logProbTrue += probTrue
+// Explain calcula a contribuição assinada de cada token do texto para cada classe.
+// A contribuição é a razão de log-verossimilhança da classe contra as demais,
+// multiplicada pelo número de ocorrências do token: log P(w|c) - média de log P(w|outra)
+// (com duas classes, a razão entre elas).
+func (c *Classifier) Explain(text string) []models.TokenContribution {
+	counts := make(map[string]int)
+	var order []string
+	for _, token := range c.tokens(text) {
+		if counts[token] == 0 {
+			order = append(order, token)
+		}
+		counts[token]++
+	}
 
-		// Probabilidade para classe "fake"
-		countFake := float64(c.WordCounts["fake"][token])
-		probFake := math.Log((countFake + alpha) / (float64(c.ClassCounts["fake"]) + alpha*vocabSize))
-		logProbFake += probFake
+	var contributions []models.TokenContribution
+	for _, token := range order {
+		n := float64(counts[token])
+		logProbs := make([]float64, len(c.Classes))
+		total := 0.0
+		for i, class := range c.Classes {
+			logProbs[i] = c.tokenLogProb(token, class)
+			total += logProbs[i]
+		}
 
-		// Contribuição do token (diferença entre as probabilidades)
-		contribution := probFake - probTrue // Negativo = mais verdadeiro, Positivo = mais falso
-		contributions = append(contributions, tokenContribution{token, contribution})
+		scores := make(map[string]float64, len(c.Classes))
+		for i, class := range c.Classes {
+			scores[class] = 0
+			if len(c.Classes) > 1 {
+				others := (total - logProbs[i]) / float64(len(c.Classes)-1)
+				scores[class] = n * (logProbs[i] - others)
+			}
+		}
+		contributions = append(contributions, models.TokenContribution{
+			Token:  token,
+			Count:  counts[token],
+			Scores: scores,
+		})
 	}
 
 	// Ordenar por contribuição (mais influentes primeiro)
-	sort.Slice(contributions, func(i, j int) bool {
-		return math.Abs(contributions[i].score) > math.Abs(contributions[j].score)
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Magnitude() > contributions[j].Magnitude()
 	})
 
-	// Extrair tokens mais influentes
-	var topTokens []string
-	for _, contrib := range contributions {
-		topTokens = append(topTokens, contrib.token)
-		if len(topTokens) >= 10 {
-			break
-		}
-	}
+	return contributions
+}
 
-	// Calcular probabilidades finais
-	probTrue := 1.0 / (1.0 + math.Exp(logProbFake-logProbTrue))
-	probFake := 1.0 - probTrue
+// Train treina o classificador (implementa models.Classifier)
+func (c *Classifier) Train(docs []models.Document) {
+	c.TrainNB(docs)
+}
 
-	probs := map[string]float64{
-		"true": probTrue * 100,
-		"fake": probFake * 100,
+// Predict classifica um texto e retorna o resultado completo (implementa models.Classifier)
+func (c *Classifier) Predict(text string) models.ClassificationResult {
+	label, confidence, probs := c.posterior(text)
+	result := models.ClassificationResult{
+		Label:         label,
+		Confidence:    confidence,
+		Probabilities: probs,
 	}
 
-	// Determinar classe e confiança
-	var label string
-	var confidence float64
-	if logProbTrue > logProbFake {
-		label = "true"
-		confidence = probTrue * 100
-	} else {
-		label = "fake"
-		confidence = probFake * 100
+	// Tokens mais influentes, das mesmas contribuições (calculadas uma única vez)
+	result.Contributions = c.Explain(text)
+	for _, contrib := range result.Contributions {
+		result.TopTokens = append(result.TopTokens, contrib.Token)
+		if len(result.TopTokens) >= 10 {
+			break
+		}
 	}
-
-	return label, confidence, probs, topTokens
+	return result
 }
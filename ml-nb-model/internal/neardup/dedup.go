@@ -0,0 +1,117 @@
+package neardup
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Estratégias para escolher o documento mantido de cada cluster
+const (
+	KeepFirst   = "first"   // o primeiro documento do dataset
+	KeepLongest = "longest" // o documento de texto mais longo
+)
+
+// Cluster é um conjunto de documentos quase-duplicados entre si (direta ou transitivamente)
+type Cluster struct {
+	Members       []int    // posições no dataset, em ordem crescente
+	Kept          int      // posição do documento mantido na deduplicação
+	Labels        []string // rótulos distintos dos membros, em ordem alfabética
+	MinSimilarity float64  // menor similaridade entre os pares que formam o cluster
+	MaxSimilarity float64  // maior similaridade entre os pares que formam o cluster
+	Dropped       bool     // descartado inteiro por misturar rótulos
+}
+
+// Conflicting indica se o cluster mistura rótulos diferentes
+func (c Cluster) Conflicting() bool {
+	return len(c.Labels) > 1
+}
+
+// DedupResult é o resultado da deduplicação de um dataset
+type DedupResult struct {
+	Clusters       []Cluster         // clusters com mais de um documento, dos maiores aos menores
+	Kept           []models.Document // dataset limpo, na ordem original
+	Removed        int               // documentos descartados
+	DroppedCluster int               // clusters descartados inteiros por conflito de rótulos
+}
+
+// Dedup agrupa os documentos quase-duplicados e mantém um documento por cluster,
+// escolhido por keep (first ou longest). Com dropConflicts, clusters que misturam
+// rótulos são descartados inteiros, já que não há rótulo confiável para eles.
+func Dedup(docs []models.Document, opts Options, keep string, dropConflicts bool) (DedupResult, error) {
+	if keep != KeepFirst && keep != KeepLongest {
+		return DedupResult{}, fmt.Errorf("estratégia desconhecida: %s (use first ou longest)", keep)
+	}
+
+	index, err := IndexDocuments(docs, opts)
+	if err != nil {
+		return DedupResult{}, err
+	}
+	pairs := index.Pairs()
+
+	// Similaridades dos pares de cada cluster
+	clusterOf := make([]int, len(docs))
+	all := Clusters(len(docs), pairs)
+	for c, members := range all {
+		for _, member := range members {
+			clusterOf[member] = c
+		}
+	}
+	similarities := make(map[int][]float64)
+	for _, pair := range pairs {
+		c := clusterOf[pair.A]
+		similarities[c] = append(similarities[c], pair.Similarity)
+	}
+
+	var result DedupResult
+	drop := make(map[int]bool)
+	for c, members := range all {
+		if len(members) < 2 {
+			continue
+		}
+
+		cluster := Cluster{Members: members, Kept: members[0]}
+		var labels []string
+		for _, member := range members {
+			labels = append(labels, docs[member].Label)
+			if keep == KeepLongest && len(docs[member].Text) > len(docs[cluster.Kept].Text) {
+				cluster.Kept = member
+			}
+		}
+		cluster.Labels = models.SortedLabels(labels)
+		cluster.MinSimilarity, cluster.MaxSimilarity = 1, 0
+		for _, similarity := range similarities[c] {
+			if similarity < cluster.MinSimilarity {
+				cluster.MinSimilarity = similarity
+			}
+			if similarity > cluster.MaxSimilarity {
+				cluster.MaxSimilarity = similarity
+			}
+		}
+
+		cluster.Dropped = dropConflicts && cluster.Conflicting()
+		for _, member := range members {
+			if member != cluster.Kept || cluster.Dropped {
+				drop[member] = true
+			}
+		}
+		if cluster.Dropped {
+			result.DroppedCluster++
+		}
+		result.Clusters = append(result.Clusters, cluster)
+	}
+
+	sort.SliceStable(result.Clusters, func(i, j int) bool {
+		return len(result.Clusters[i].Members) > len(result.Clusters[j].Members)
+	})
+
+	for i, doc := range docs {
+		if drop[i] {
+			result.Removed++
+			continue
+		}
+		result.Kept = append(result.Kept, doc)
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package neardup
+
+import "github.com/souza/esw-008/ml-nb-model/internal/models"
+
+// unionFind agrupa índices em componentes conexos
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+func (u *unionFind) union(a, b int) {
+	ra, rb := u.find(a), u.find(b)
+	// A raiz é sempre o menor índice, para que o componente herde o grupo
+	// do documento que aparece primeiro
+	if ra < rb {
+		u.parent[rb] = ra
+	} else if rb < ra {
+		u.parent[ra] = rb
+	}
+}
+
+// IndexDocuments indexa o texto de todos os documentos, na ordem recebida
+func IndexDocuments(docs []models.Document, opts Options) (*Index, error) {
+	index, err := NewIndex(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		index.Add(doc.ID, doc.Text)
+	}
+	return index, nil
+}
+
+// Clusters retorna os componentes conexos formados pelos pares de
+// quase-duplicados, como listas de posições em ordem crescente
+// (documentos sem quase-duplicados formam componentes unitários)
+func Clusters(n int, pairs []Pair) [][]int {
+	uf := newUnionFind(n)
+	for _, pair := range pairs {
+		uf.union(pair.A, pair.B)
+	}
+
+	position := make(map[int]int)
+	var clusters [][]int
+	for i := 0; i < n; i++ {
+		root := uf.find(i)
+		p, seen := position[root]
+		if !seen {
+			p = len(clusters)
+			position[root] = p
+			clusters = append(clusters, nil)
+		}
+		clusters[p] = append(clusters[p], i)
+	}
+	return clusters
+}
+
+// GroupDocuments une em um mesmo grupo (Metadata["group"]) os documentos
+// quase-duplicados e os grupos que já os continham, para que a validação
+// cruzada nunca os separe entre treino e teste. Retorna uma cópia dos
+// documentos e o número de grupos originais que foram fundidos.
+func GroupDocuments(docs []models.Document, opts Options) ([]models.Document, int, error) {
+	index, err := IndexDocuments(docs, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	uf := newUnionFind(len(docs))
+	firstOfGroup := make(map[string]int)
+	for i, doc := range docs {
+		if first, seen := firstOfGroup[doc.Group()]; seen {
+			uf.union(first, i)
+		} else {
+			firstOfGroup[doc.Group()] = i
+		}
+	}
+
+	merged := 0
+	for _, pair := range index.Pairs() {
+		if uf.find(pair.A) != uf.find(pair.B) {
+			merged++
+			uf.union(pair.A, pair.B)
+		}
+	}
+
+	grouped := make([]models.Document, len(docs))
+	for i, doc := range docs {
+		root := docs[uf.find(i)].Group()
+		metadata := make(map[string]string, len(doc.Metadata)+1)
+		for key, value := range doc.Metadata {
+			metadata[key] = value
+		}
+		metadata[models.GroupKey] = root
+		doc.Metadata = metadata
+		grouped[i] = doc
+	}
+	return grouped, merged, nil
+}
@@ -0,0 +1,158 @@
+package neardup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// Match é um documento do índice semelhante ao documento consultado
+type Match struct {
+	Index      int // posição do documento no índice
+	ID         string
+	Similarity float64 // similaridade de Jaccard exata dos shingles
+}
+
+// Pair é um par de quase-duplicados do índice (A < B)
+type Pair struct {
+	A, B       int
+	Similarity float64
+}
+
+// entry é um documento indexado
+type entry struct {
+	id        string
+	shingles  []uint64
+	signature []uint64
+}
+
+// Index é um índice LSH de assinaturas MinHash: documentos que coincidem em
+// todas as linhas de ao menos uma banda são candidatos, confirmados pela
+// similaridade de Jaccard exata dos shingles
+type Index struct {
+	Options
+	hasher  *Hasher
+	entries []entry
+	buckets []map[uint64][]int // [banda][hash da banda] -> documentos
+}
+
+// NewIndex cria um índice vazio
+func NewIndex(opts Options) (*Index, error) {
+	if opts.NumHashes < 1 || opts.Bands < 1 || opts.NumHashes%opts.Bands != 0 {
+		return nil, fmt.Errorf("o número de hashes (%d) deve ser múltiplo do número de bandas (%d)", opts.NumHashes, opts.Bands)
+	}
+	if opts.Threshold <= 0 || opts.Threshold > 1 {
+		return nil, fmt.Errorf("limiar de similaridade inválido: %g (use um valor em (0, 1])", opts.Threshold)
+	}
+
+	buckets := make([]map[uint64][]int, opts.Bands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]int)
+	}
+	return &Index{
+		Options: opts,
+		hasher:  NewHasher(opts.NumHashes, opts.Seed),
+		buckets: buckets,
+	}, nil
+}
+
+// Add indexa um documento e retorna a sua posição no índice
+func (ix *Index) Add(id, text string) int {
+	shingles := TextShingles(text, ix.Shingle)
+	e := entry{id: id, shingles: shingles, signature: ix.hasher.Signature(shingles)}
+	index := len(ix.entries)
+	ix.entries = append(ix.entries, e)
+
+	if len(shingles) > 0 {
+		for band, key := range ix.bandKeys(e.signature) {
+			ix.buckets[band][key] = append(ix.buckets[band][key], index)
+		}
+	}
+	return index
+}
+
+// Len retorna o número de documentos indexados
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+// ID retorna o identificador do documento na posição informada
+func (ix *Index) ID(index int) string {
+	return ix.entries[index].id
+}
+
+// Query retorna os documentos do índice com similaridade ≥ Threshold em
+// relação ao texto, do mais ao menos semelhante
+func (ix *Index) Query(text string) []Match {
+	shingles := TextShingles(text, ix.Shingle)
+	if len(shingles) == 0 {
+		return nil
+	}
+	signature := ix.hasher.Signature(shingles)
+
+	var matches []Match
+	for _, candidate := range ix.candidates(signature) {
+		if similarity := Jaccard(shingles, ix.entries[candidate].shingles); similarity >= ix.Threshold {
+			matches = append(matches, Match{Index: candidate, ID: ix.entries[candidate].id, Similarity: similarity})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	return matches
+}
+
+// Pairs retorna todos os pares de quase-duplicados do índice (similaridade ≥ Threshold)
+func (ix *Index) Pairs() []Pair {
+	var pairs []Pair
+	for a, e := range ix.entries {
+		if len(e.shingles) == 0 {
+			continue
+		}
+		for _, b := range ix.candidates(e.signature) {
+			if b <= a {
+				continue
+			}
+			if similarity := Jaccard(e.shingles, ix.entries[b].shingles); similarity >= ix.Threshold {
+				pairs = append(pairs, Pair{A: a, B: b, Similarity: similarity})
+			}
+		}
+	}
+	return pairs
+}
+
+// candidates retorna os documentos que compartilham ao menos uma banda com a assinatura
+func (ix *Index) candidates(signature []uint64) []int {
+	seen := make(map[int]bool)
+	var candidates []int
+	for band, key := range ix.bandKeys(signature) {
+		for _, index := range ix.buckets[band][key] {
+			if !seen[index] {
+				seen[index] = true
+				candidates = append(candidates, index)
+			}
+		}
+	}
+	sort.Ints(candidates)
+	return candidates
+}
+
+// bandKeys calcula o hash de cada banda da assinatura
+func (ix *Index) bandKeys(signature []uint64) []uint64 {
+	rows := ix.NumHashes / ix.Bands
+	keys := make([]uint64, ix.Bands)
+	buf := make([]byte, 8*rows)
+	for band := range keys {
+		for r := 0; r < rows; r++ {
+			value := signature[band*rows+r]
+			for b := 0; b < 8; b++ {
+				buf[r*8+b] = byte(value >> (8 * b))
+			}
+		}
+		h := fnv.New64a()
+		h.Write(buf)
+		keys[band] = h.Sum64()
+	}
+	return keys
+}
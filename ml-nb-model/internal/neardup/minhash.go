@@ -0,0 +1,145 @@
+package neardup
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Options configura a detecção de quase-duplicados
+type Options struct {
+	Shingle   int     // tamanho dos shingles, em tokens
+	NumHashes int     // funções de hash da assinatura MinHash
+	Bands     int     // bandas do LSH (NumHashes deve ser múltiplo de Bands)
+	Threshold float64 // similaridade de Jaccard mínima entre quase-duplicados
+	Seed      int64   // semente das funções de hash
+}
+
+// DefaultOptions retorna a configuração padrão: shingles de 3 tokens, 128 hashes
+// em 32 bandas de 4 linhas (candidatos a partir de Jaccard ≈ 0,42) e limiar 0,8
+func DefaultOptions() Options {
+	return Options{
+		Shingle:   3,
+		NumHashes: 128,
+		Bands:     32,
+		Threshold: 0.8,
+		Seed:      1,
+	}
+}
+
+// Shingles retorna o conjunto ordenado de hashes dos shingles de k tokens
+// (textos com menos de k tokens formam um único shingle)
+func Shingles(tokens []string, k int) []uint64 {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	seen := make(map[uint64]bool)
+	var shingles []uint64
+	for i := 0; i == 0 || i+k <= len(tokens); i++ {
+		end := i + k
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(tokens[i:end], " ")))
+		value := h.Sum64()
+		if !seen[value] {
+			seen[value] = true
+			shingles = append(shingles, value)
+		}
+	}
+
+	sort.Slice(shingles, func(i, j int) bool { return shingles[i] < shingles[j] })
+	return shingles
+}
+
+// TextShingles retorna os shingles de um texto após PreprocessText
+func TextShingles(text string, k int) []uint64 {
+	return Shingles(utils.PreprocessText(text), k)
+}
+
+// Jaccard calcula a similaridade de Jaccard exata entre dois conjuntos ordenados
+func Jaccard(a, b []uint64) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			intersection++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+// Hasher calcula assinaturas MinHash
+type Hasher struct {
+	seeds []uint64
+}
+
+// NewHasher cria um Hasher com n funções de hash derivadas da semente
+func NewHasher(n int, seed int64) *Hasher {
+	rng := rand.New(rand.NewSource(seed))
+	seeds := make([]uint64, n)
+	for i := range seeds {
+		seeds[i] = rng.Uint64()
+	}
+	return &Hasher{seeds: seeds}
+}
+
+// Signature calcula a assinatura MinHash de um conjunto de shingles: para cada
+// função de hash, o menor valor entre os shingles. A fração de posições iguais
+// entre duas assinaturas estima a similaridade de Jaccard dos conjuntos.
+func (h *Hasher) Signature(shingles []uint64) []uint64 {
+	signature := make([]uint64, len(h.seeds))
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for _, shingle := range shingles {
+		for i, seed := range h.seeds {
+			if value := mix(shingle ^ seed); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+	return signature
+}
+
+// EstimateSimilarity estima a similaridade de Jaccard pela fração de posições iguais
+func EstimateSimilarity(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// mix embaralha os bits de um valor (finalizador do splitmix64)
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,53 @@
+package neardup
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// WriteReport grava os clusters em CSV, uma linha por documento: cluster,
+// tamanho, se o cluster mistura rótulos, se o documento foi mantido,
+// id, rótulo, título, URL e o início do texto
+func WriteReport(w io.Writer, docs []models.Document, result DedupResult) error {
+	writer := csv.NewWriter(w)
+	header := []string{"cluster", "size", "conflicting", "kept", "id", "label", "title", "url", "text"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for c, cluster := range result.Clusters {
+		for _, member := range cluster.Members {
+			doc := docs[member]
+			row := []string{
+				strconv.Itoa(c + 1),
+				strconv.Itoa(len(cluster.Members)),
+				strconv.FormatBool(cluster.Conflicting()),
+				strconv.FormatBool(member == cluster.Kept && !cluster.Dropped),
+				doc.ID,
+				doc.Label,
+				doc.Title,
+				doc.URL,
+				snippet(doc.Text, 200),
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// snippet retorna o início do texto em uma única linha, com no máximo n caracteres
+func snippet(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > n {
+		return string(runes[:n]) + "..."
+	}
+	return text
+}
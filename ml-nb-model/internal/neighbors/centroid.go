@@ -0,0 +1,122 @@
+package neighbors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/retrieval"
+)
+
+// Centroid é um classificador de centroide mais próximo (Rocchio): cada classe é
+// representada pela média dos vetores TF-IDF de norma unitária dos seus
+// documentos, e o texto é atribuído à classe de centroide mais semelhante
+// (implementa models.Classifier)
+type Centroid struct {
+	Scale     float64 // escala das similaridades no softmax das probabilidades
+	Index     *retrieval.Index
+	Classes   []string
+	Centroids map[string]map[string]float64 // [classe][termo], com norma unitária
+	shared    *retrieval.Index              // índice recebido em NewCentroid (nil = indexar em Train)
+}
+
+// NewCentroid cria um classificador de centroide mais próximo. index (opcional) é o
+// índice de NewIndex já construído com os documentos que serão passados a Train;
+// com nil, Train indexa os documentos recebidos.
+func NewCentroid(scale float64, index *retrieval.Index) (*Centroid, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("a escala deve ser positiva")
+	}
+	return &Centroid{Scale: scale, shared: index}, nil
+}
+
+// Train indexa os documentos e calcula o centroide de cada classe
+func (c *Centroid) Train(docs []models.Document) {
+	index := c.shared
+	if index == nil {
+		index = NewIndex(docs)
+	}
+	c.Index = index
+	c.Classes = models.Labels(docs)
+
+	c.Centroids = make(map[string]map[string]float64, len(c.Classes))
+	for _, label := range c.Classes {
+		c.Centroids[label] = make(map[string]float64)
+	}
+	for i, vector := range index.DocumentVectors() {
+		centroid := c.Centroids[index.Docs[i].Label]
+		for term, value := range vector {
+			centroid[term] += value
+		}
+	}
+	for _, centroid := range c.Centroids {
+		norm := 0.0
+		for _, value := range centroid {
+			norm += value * value
+		}
+		if norm == 0 {
+			continue
+		}
+		norm = math.Sqrt(norm)
+		for term := range centroid {
+			centroid[term] /= norm
+		}
+	}
+
+	models.Logf("Centroide: %d documentos, %d classes\n", index.Len(), len(c.Classes))
+}
+
+// Similarities retorna a similaridade de cosseno do texto com o centroide de cada classe
+func (c *Centroid) Similarities(text string) map[string]float64 {
+	query := c.Index.Vector(text)
+	similarities := make(map[string]float64, len(c.Classes))
+	for _, label := range c.Classes {
+		for term, value := range query {
+			similarities[label] += value * c.Centroids[label][term]
+		}
+	}
+	return similarities
+}
+
+// Predict classifica um texto pelo centroide mais semelhante
+func (c *Centroid) Predict(text string) models.ClassificationResult {
+	result := resultFromProbabilities(c.Classes, c.Probabilities(text))
+	result.Contributions = c.Explain(text)
+	result.TopTokens = topTokens(result.Contributions)
+	return result
+}
+
+// Probabilities converte as similaridades com os centroides por softmax
+// (multiplicadas por Scale), sem as contribuições dos tokens (implementa
+// models.ProbabilityModel); não são probabilidades calibradas
+func (c *Centroid) Probabilities(text string) map[string]float64 {
+	similarities := c.Similarities(text)
+
+	probs := make(map[string]float64, len(c.Classes))
+	maxScore := math.Inf(-1)
+	for _, label := range c.Classes {
+		maxScore = math.Max(maxScore, similarities[label]*c.Scale)
+	}
+	sum := 0.0
+	for _, label := range c.Classes {
+		probs[label] = math.Exp(similarities[label]*c.Scale - maxScore)
+		sum += probs[label]
+	}
+	for _, label := range c.Classes {
+		probs[label] = probs[label] / sum * 100
+	}
+	return probs
+}
+
+// Explain retorna a contribuição de cada token para a similaridade com cada
+// centroide: o peso do termo no texto vezes o seu peso no centroide
+func (c *Centroid) Explain(text string) []models.TokenContribution {
+	scores := make(map[string]map[string]float64)
+	for term, value := range c.Index.Vector(text) {
+		scores[term] = make(map[string]float64, len(c.Classes))
+		for _, label := range c.Classes {
+			scores[term][label] = value * c.Centroids[label][term]
+		}
+	}
+	return contributions(text, c.Classes, scores)
+}
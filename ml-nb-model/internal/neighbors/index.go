@@ -0,0 +1,14 @@
+package neighbors
+
+import (
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/retrieval"
+)
+
+// NewIndex constrói o índice TF-IDF usado pelo kNN e pelo centroide. Quem treina os
+// dois com os mesmos documentos pode construí-lo uma vez e passá-lo a NewKNN e NewCentroid.
+func NewIndex(docs []models.Document) *retrieval.Index {
+	// A pontuação TF-IDF é sempre válida; NewIndex não falha
+	index, _ := retrieval.NewIndex(docs, retrieval.Options{Scoring: retrieval.ScoringTFIDF})
+	return index
+}
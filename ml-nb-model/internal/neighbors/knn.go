@@ -0,0 +1,181 @@
+package neighbors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/retrieval"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Ponderações dos votos do kNN
+const (
+	WeightingUniform = "uniform" // um voto por vizinho
+	WeightingCosine  = "cosine"  // voto proporcional à similaridade de cosseno
+)
+
+// KNN é um classificador k-vizinhos mais próximos sobre vetores TF-IDF com
+// similaridade de cosseno (implementa models.Classifier). Os vizinhos vêm do
+// índice invertido de retrieval, que só visita documentos com termos em comum
+// e mantém apenas os k melhores em um heap.
+type KNN struct {
+	K         int
+	Weighting string
+	Index     *retrieval.Index
+	Classes   []string
+	shared    *retrieval.Index // índice recebido em NewKNN (nil = indexar em Train)
+}
+
+// NewKNN cria um classificador kNN com k vizinhos e a ponderação informada. index
+// (opcional) é o índice de NewIndex já construído com os documentos que serão
+// passados a Train; com nil, Train indexa os documentos recebidos.
+func NewKNN(k int, weighting string, index *retrieval.Index) (*KNN, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("k deve ser positivo")
+	}
+	if weighting != WeightingUniform && weighting != WeightingCosine {
+		return nil, fmt.Errorf("ponderação desconhecida: %s (use uniform ou cosine)", weighting)
+	}
+	return &KNN{K: k, Weighting: weighting, shared: index}, nil
+}
+
+// Train indexa os documentos de treinamento (ou usa o índice recebido em NewKNN)
+func (c *KNN) Train(docs []models.Document) {
+	index := c.shared
+	if index == nil {
+		index = NewIndex(docs)
+	}
+	c.Index = index
+	c.Classes = models.Labels(docs)
+	models.Logf("kNN: %d documentos indexados (k=%d, votos %s)\n", index.Len(), c.K, c.Weighting)
+}
+
+// Neighbors retorna os k documentos de treinamento mais semelhantes ao texto
+func (c *KNN) Neighbors(text string) []retrieval.Hit {
+	return c.Index.Search(text, c.K, nil)
+}
+
+// Predict classifica um texto pelos votos dos k vizinhos mais próximos; as
+// probabilidades são a fração dos votos de cada classe (sem vizinhos com termos
+// em comum, as classes ficam equiprováveis)
+func (c *KNN) Predict(text string) models.ClassificationResult {
+	neighbors := c.Neighbors(text)
+	result := resultFromProbabilities(c.Classes, c.probabilities(neighbors))
+	result.Contributions = c.explain(text, neighbors)
+	result.TopTokens = topTokens(result.Contributions)
+	return result
+}
+
+// Probabilities retorna a fração dos votos de cada classe, sem as contribuições
+// dos tokens (implementa models.ProbabilityModel)
+func (c *KNN) Probabilities(text string) map[string]float64 {
+	return c.probabilities(c.Neighbors(text))
+}
+
+// probabilities converte os votos dos vizinhos em probabilidades (0-100)
+func (c *KNN) probabilities(neighbors []retrieval.Hit) map[string]float64 {
+	votes := make(map[string]float64, len(c.Classes))
+	total := 0.0
+	for _, hit := range neighbors {
+		vote := c.vote(hit)
+		votes[hit.Document.Label] += vote
+		total += vote
+	}
+
+	probs := make(map[string]float64, len(c.Classes))
+	for _, label := range c.Classes {
+		if total > 0 {
+			probs[label] = votes[label] / total * 100
+		} else {
+			probs[label] = 100 / float64(len(c.Classes))
+		}
+	}
+	return probs
+}
+
+// Explain retorna a contribuição de cada token para os votos de cada classe:
+// a parcela da similaridade de cosseno com cada vizinho devida ao token,
+// somada entre os vizinhos da classe (ponderada como os votos)
+func (c *KNN) Explain(text string) []models.TokenContribution {
+	return c.explain(text, c.Neighbors(text))
+}
+
+func (c *KNN) explain(text string, neighbors []retrieval.Hit) []models.TokenContribution {
+	scores := make(map[string]map[string]float64)
+	for _, hit := range neighbors {
+		scale := 1.0
+		if c.Weighting == WeightingUniform && hit.Score > 0 {
+			scale = 1 / hit.Score
+		}
+		for term, contribution := range hit.Contributions {
+			if scores[term] == nil {
+				scores[term] = make(map[string]float64, len(c.Classes))
+			}
+			scores[term][hit.Document.Label] += contribution * scale
+		}
+	}
+	return contributions(text, c.Classes, scores)
+}
+
+// vote retorna o peso do voto de um vizinho
+func (c *KNN) vote(hit retrieval.Hit) float64 {
+	if c.Weighting == WeightingCosine {
+		return hit.Score
+	}
+	return 1
+}
+
+// resultFromProbabilities escolhe a classe mais provável (desempate alfabético)
+func resultFromProbabilities(classes []string, probs map[string]float64) models.ClassificationResult {
+	result := models.ClassificationResult{Probabilities: probs}
+	for _, label := range classes {
+		if result.Label == "" || probs[label] > result.Confidence {
+			result.Label = label
+			result.Confidence = probs[label]
+		}
+	}
+	return result
+}
+
+// contributions monta as contribuições dos tokens do texto a partir das
+// pontuações por termo e classe, das mais às menos influentes
+func contributions(text string, classes []string, scores map[string]map[string]float64) []models.TokenContribution {
+	counts := make(map[string]int)
+	var order []string
+	for _, token := range utils.PreprocessText(text) {
+		if scores[token] == nil {
+			continue
+		}
+		if counts[token] == 0 {
+			order = append(order, token)
+		}
+		counts[token]++
+	}
+
+	var result []models.TokenContribution
+	for _, token := range order {
+		contrib := models.TokenContribution{Token: token, Count: counts[token], Scores: make(map[string]float64, len(classes))}
+		for _, label := range classes {
+			contrib.Scores[label] = scores[token][label]
+		}
+		result = append(result, contrib)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Magnitude() > result[j].Magnitude()
+	})
+	return result
+}
+
+// topTokens retorna os 10 tokens mais influentes
+func topTokens(contributions []models.TokenContribution) []string {
+	var tokens []string
+	for _, contrib := range contributions {
+		tokens = append(tokens, contrib.Token)
+		if len(tokens) >= 10 {
+			break
+		}
+	}
+	return tokens
+}
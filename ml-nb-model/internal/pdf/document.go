@@ -0,0 +1,233 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// object representa um objeto indireto (dicionário/valor e, opcionalmente, stream)
+type object struct {
+	value  interface{}
+	stream []byte // dados brutos do stream (ainda codificados)
+}
+
+// document guarda os objetos indiretos encontrados no arquivo
+type document struct {
+	objects map[int]*object
+}
+
+// objRegex localiza o início de objetos indiretos "n g obj"
+var objRegex = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// parseDocument varre o arquivo em busca de objetos indiretos.
+// A tabela xref é ignorada: isso torna a leitura tolerante a arquivos
+// com offsets incorretos, e atualizações incrementais sobrescrevem
+// objetos antigos por aparecerem depois no arquivo.
+func parseDocument(data []byte) (*document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \r\n\t"), []byte("%PDF-")) {
+		return nil, errors.New("arquivo não é um PDF")
+	}
+
+	doc := &document{objects: make(map[int]*object)}
+
+	for _, loc := range objRegex.FindAllSubmatchIndex(data, -1) {
+		num, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		l := &lexer{data: data, pos: loc[1]}
+		obj := &object{value: l.parseObject()}
+
+		// Verificar se o objeto possui stream
+		save := l.pos
+		if kw, ok := l.next().(keyword); ok && kw == "stream" {
+			obj.stream = readStream(data, l.pos, obj.value)
+		} else {
+			l.pos = save
+		}
+
+		doc.objects[num] = obj
+	}
+
+	// Expandir object streams (PDF 1.5+)
+	for _, obj := range doc.objects {
+		d, ok := obj.value.(dict)
+		if !ok || d["Type"] != name("ObjStm") {
+			continue
+		}
+		doc.expandObjectStream(obj)
+	}
+
+	if len(doc.objects) == 0 {
+		return nil, errors.New("nenhum objeto encontrado no PDF")
+	}
+	return doc, nil
+}
+
+// readStream obtém os dados brutos de um stream a partir da posição após "stream"
+func readStream(data []byte, pos int, value interface{}) []byte {
+	// O stream começa após o fim de linha que segue a palavra-chave
+	if pos < len(data) && data[pos] == '\r' {
+		pos++
+	}
+	if pos < len(data) && data[pos] == '\n' {
+		pos++
+	}
+
+	if d, ok := value.(dict); ok {
+		// Rejeitar comprimentos negativos ou além do fim antes de converter para int
+		// (um /Length enorme estouraria a conversão)
+		if length, ok := d["Length"].(float64); ok && length >= 0 && length <= float64(len(data)-pos) {
+			end := pos + int(length)
+			if bytes.Contains(data[end:min(end+32, len(data))], []byte("endstream")) {
+				return data[pos:end]
+			}
+		}
+	}
+
+	// Comprimento indireto ou incorreto: procurar por "endstream"
+	end := bytes.Index(data[pos:], []byte("endstream"))
+	if end < 0 {
+		return data[pos:]
+	}
+	return bytes.TrimRight(data[pos:pos+end], "\r\n")
+}
+
+// expandObjectStream adiciona ao documento os objetos comprimidos em um ObjStm
+func (doc *document) expandObjectStream(obj *object) {
+	d := obj.value.(dict)
+	data, err := doc.decodeStream(obj)
+	if err != nil {
+		return
+	}
+
+	n, _ := d["N"].(float64)
+	first, _ := d["First"].(float64)
+	if !isIndex(n, len(data)) || !isIndex(first, len(data)) {
+		return
+	}
+
+	header := &lexer{data: data}
+	for i := 0; i < int(n); i++ {
+		num, ok1 := header.next().(float64)
+		offset, ok2 := header.next().(float64)
+		if !ok1 || !ok2 {
+			return
+		}
+		if !isIndex(offset, len(data)) {
+			continue
+		}
+		start := int(first) + int(offset)
+		if start < 0 || start >= len(data) {
+			continue
+		}
+		// Objetos do ObjStm não sobrescrevem objetos definidos diretamente no arquivo
+		if _, exists := doc.objects[int(num)]; exists {
+			continue
+		}
+		l := &lexer{data: data, pos: start}
+		doc.objects[int(num)] = &object{value: l.parseObject()}
+	}
+}
+
+// isIndex indica se v é um inteiro não negativo de no máximo limit (seguro para converter em int)
+func isIndex(v float64, limit int) bool {
+	return v >= 0 && v <= float64(limit) && v == math.Trunc(v)
+}
+
+// resolve segue referências indiretas
+func (doc *document) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		obj, exists := doc.objects[r.n]
+		if !exists {
+			return nil
+		}
+		v = obj.value
+	}
+	return nil
+}
+
+// dictOf resolve um valor e o converte em dicionário
+func (doc *document) dictOf(v interface{}) dict {
+	d, _ := doc.resolve(v).(dict)
+	return d
+}
+
+// decodeStream aplica os filtros do stream
+func (doc *document) decodeStream(obj *object) ([]byte, error) {
+	d, _ := obj.value.(dict)
+	data := obj.stream
+
+	var filters []name
+	switch f := doc.resolve(d["Filter"]).(type) {
+	case name:
+		filters = []name{f}
+	case array:
+		for _, item := range f {
+			if n, ok := doc.resolve(item).(name); ok {
+				filters = append(filters, n)
+			}
+		}
+	}
+
+	for _, filter := range filters {
+		var err error
+		switch filter {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+		case "ASCIIHexDecode", "AHx":
+			data = (&lexer{data: append([]byte("<"), data...)}).readHexString()
+		case "ASCII85Decode", "A85":
+			data, err = decodeASCII85(data)
+		default:
+			err = fmt.Errorf("filtro não suportado: %s", filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// maxStreamSize limita o tamanho de um stream descomprimido, para que um stream
+// pequeno e muito comprimido (zip bomb) não esgote a memória
+const maxStreamSize = 64 << 20
+
+// inflate descomprime dados FlateDecode (zlib, com fallback para deflate puro)
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		r = flate.NewReader(bytes.NewReader(data))
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, maxStreamSize+1))
+	if len(out) > maxStreamSize {
+		return nil, fmt.Errorf("stream descomprimido excede %d MB", maxStreamSize>>20)
+	}
+	if len(out) > 0 {
+		// Streams truncados são comuns: aproveitar o que foi descomprimido
+		return out, nil
+	}
+	return out, err
+}
+
+// decodeASCII85 decodifica dados ASCII85 terminados por "~>"
+func decodeASCII85(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out := make([]byte, len(data)*4/5+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
@@ -0,0 +1,232 @@
+package pdf
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Tipos dos objetos PDF
+type (
+	name    string          // /Nome (sem a barra)
+	keyword string          // operador ou palavra-chave (obj, R, Tj, BT...)
+	ref     struct{ n int } // referência indireta "n g R"
+	dict    map[string]interface{}
+	array   []interface{}
+)
+
+// lexer percorre os bytes de um objeto ou de um content stream
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+// isWhite indica se o byte é espaço em branco no PDF
+func isWhite(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\f' || b == 0
+}
+
+// isDelim indica se o byte é um delimitador no PDF
+func isDelim(b byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), b) >= 0
+}
+
+// skipSpace ignora espaços e comentários
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		if isWhite(b) {
+			l.pos++
+		} else if b == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		} else {
+			return
+		}
+	}
+}
+
+// next retorna o próximo token (ou nil no fim dos dados)
+func (l *lexer) next() interface{} {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil
+	}
+
+	b := l.data[l.pos]
+	switch {
+	case b == '(':
+		return l.readString()
+	case b == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return keyword("<<")
+	case b == '<':
+		return l.readHexString()
+	case b == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+		l.pos += 2
+		return keyword(">>")
+	case b == '/':
+		l.pos++
+		return name(l.readRegular())
+	case b == '[' || b == ']' || b == '{' || b == '}':
+		l.pos++
+		return keyword(string(b))
+	case isDelim(b):
+		// Delimitador solto (dados malformados): ignorar
+		l.pos++
+		return keyword("")
+	}
+
+	word := l.readRegular()
+	if num, err := strconv.ParseFloat(word, 64); err == nil {
+		return num
+	}
+	switch word {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return keyword(word)
+}
+
+// readRegular lê uma sequência de caracteres regulares
+func (l *lexer) readRegular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isWhite(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// readString lê uma string literal "(...)" tratando escapes e parênteses aninhados
+func (l *lexer) readString() []byte {
+	l.pos++ // '('
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		switch b {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// readHexString lê uma string hexadecimal "<...>"
+func (l *lexer) readHexString() []byte {
+	l.pos++ // '<'
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if !isWhite(l.data[l.pos]) {
+			digits = append(digits, l.data[l.pos])
+		}
+		l.pos++
+	}
+	l.pos++ // '>'
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		v, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			continue
+		}
+		out = append(out, byte(v))
+	}
+	return out
+}
+
+// parseObject lê um objeto completo (dicionário, array, referência ou valor simples)
+func (l *lexer) parseObject() interface{} {
+	return l.build(l.next())
+}
+
+// build transforma o token atual em objeto, consumindo os tokens seguintes se necessário
+func (l *lexer) build(tok interface{}) interface{} {
+	switch t := tok.(type) {
+	case keyword:
+		switch t {
+		case "<<":
+			d := dict{}
+			for {
+				key := l.next()
+				if key == nil || key == keyword(">>") {
+					return d
+				}
+				k, ok := key.(name)
+				if !ok {
+					continue
+				}
+				d[string(k)] = l.parseObject()
+			}
+		case "[":
+			var a array
+			for {
+				item := l.next()
+				if item == nil || item == keyword("]") {
+					return a
+				}
+				a = append(a, l.build(item))
+			}
+		}
+		return t
+	case float64:
+		// Verificar se é uma referência "n g R"
+		save := l.pos
+		gen, ok := l.next().(float64)
+		if ok && gen >= 0 {
+			if r, ok := l.next().(keyword); ok && r == "R" {
+				return ref{n: int(t)}
+			}
+		}
+		l.pos = save
+		return t
+	}
+	return tok
+}
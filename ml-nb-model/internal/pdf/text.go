@@ -0,0 +1,505 @@
+package pdf
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// font guarda o necessário para decodificar as strings de uma fonte
+type font struct {
+	toUnicode map[string]string // código (bytes) -> texto
+	codeBytes int               // tamanho do código em bytes (1 ou 2)
+}
+
+// decode converte uma string do content stream em texto
+func (f *font) decode(s []byte) string {
+	if f == nil || f.toUnicode == nil {
+		return decodeWinAnsi(s)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		n := f.codeBytes
+		if i+n > len(s) {
+			n = len(s) - i
+		}
+		if text, ok := f.toUnicode[string(s[i:i+n])]; ok {
+			sb.WriteString(text)
+		} else if n == 1 {
+			sb.WriteString(decodeWinAnsi(s[i : i+1]))
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+// winAnsiHigh mapeia os códigos 0x80-0x9F do WinAnsiEncoding
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '\'',
+	0x92: '\'', 0x93: '"', 0x94: '"', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜',
+	0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// decodeWinAnsi decodifica bytes de fontes simples (WinAnsi/Latin-1)
+func decodeWinAnsi(s []byte) string {
+	runes := make([]rune, 0, len(s))
+	for _, b := range s {
+		if r, ok := winAnsiHigh[b]; ok {
+			runes = append(runes, r)
+		} else if b >= 0x20 || b == '\n' || b == '\t' {
+			runes = append(runes, rune(b))
+		}
+	}
+	return string(runes)
+}
+
+// decodeUTF16 decodifica o destino de um mapeamento ToUnicode (UTF-16BE)
+func decodeUTF16(b []byte) string {
+	if len(b)%2 == 1 {
+		b = append([]byte{0}, b...)
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return string(utf16.Decode(units))
+}
+
+// parseCMap lê um CMap ToUnicode (bfchar e bfrange)
+func parseCMap(data []byte) *font {
+	f := &font{toUnicode: make(map[string]string), codeBytes: 1}
+	l := &lexer{data: data}
+
+	var operands []interface{}
+	mode := ""
+	for {
+		tok := l.next()
+		if tok == nil {
+			break
+		}
+		kw, isKeyword := tok.(keyword)
+		if !isKeyword || kw == "[" {
+			operands = append(operands, l.build(tok))
+			continue
+		}
+
+		switch kw {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			mode = string(kw)
+			operands = nil
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if lo, ok := operands[0].([]byte); ok && len(lo) > 0 {
+					f.codeBytes = len(lo)
+				}
+			}
+			mode = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					f.toUnicode[string(src)] = decodeUTF16(dst)
+				}
+			}
+			mode = ""
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				addBFRange(f, operands[i], operands[i+1], operands[i+2])
+			}
+			mode = ""
+		default:
+			if mode == "" {
+				operands = nil
+			}
+		}
+	}
+	return f
+}
+
+// addBFRange adiciona um intervalo "<lo> <hi> <dst>" ou "<lo> <hi> [<d1> <d2> ...]"
+func addBFRange(f *font, loObj, hiObj, dstObj interface{}) {
+	lo, ok1 := loObj.([]byte)
+	hi, ok2 := hiObj.([]byte)
+	if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 {
+		return
+	}
+
+	start, end := codeValue(lo), codeValue(hi)
+	if end < start || end-start > 0xFFFF {
+		return
+	}
+
+	for code := start; code <= end; code++ {
+		key := codeBytes(code, len(lo))
+		switch dst := dstObj.(type) {
+		case []byte:
+			if len(dst) == 0 {
+				continue
+			}
+			// Incrementar o último byte do destino
+			out := append([]byte(nil), dst...)
+			last := int(out[len(out)-1]) + (code - start)
+			out[len(out)-1] = byte(last)
+			if len(out) >= 2 {
+				out[len(out)-2] += byte(last >> 8)
+			}
+			f.toUnicode[key] = decodeUTF16(out)
+		case array:
+			if idx := code - start; idx < len(dst) {
+				if b, ok := dst[idx].([]byte); ok {
+					f.toUnicode[key] = decodeUTF16(b)
+				}
+			}
+		}
+	}
+}
+
+// codeValue converte bytes big-endian em inteiro
+func codeValue(b []byte) int {
+	v := 0
+	for _, c := range b {
+		v = v<<8 | int(c)
+	}
+	return v
+}
+
+// codeBytes converte um inteiro em n bytes big-endian
+func codeBytes(v, n int) string {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return string(b)
+}
+
+// ExtractText extrai o texto de um documento PDF, página por página
+func ExtractText(data []byte) (string, error) {
+	doc, err := parseDocument(data)
+	if err != nil {
+		return "", err
+	}
+
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return "", errors.New("nenhuma página encontrada no PDF")
+	}
+
+	var parts []string
+	for _, page := range pages {
+		e := &extractor{doc: doc, fonts: make(map[int]*font)}
+		e.runContents(page["Contents"], doc.dictOf(page["Resources"]), 0)
+		if text := cleanText(e.out.String()); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// pages retorna os dicionários das páginas na ordem da árvore /Pages
+func (doc *document) pages() []dict {
+	var root dict
+	visited := make(map[int]bool)
+	for _, obj := range doc.objects {
+		if d, ok := obj.value.(dict); ok && d["Type"] == name("Catalog") {
+			root = doc.dictOf(d["Pages"])
+			if r, ok := d["Pages"].(ref); ok {
+				visited[r.n] = true
+			}
+			break
+		}
+	}
+
+	// Cada nó é visitado uma única vez: árvores com ciclos ou com o mesmo filho
+	// repetido ("/Kids [2 0 R 2 0 R]") cresceriam exponencialmente
+	var pages []dict
+	var walk func(node dict, inherited dict, depth int)
+	walk = func(node dict, inherited dict, depth int) {
+		if node == nil || depth > 64 {
+			return
+		}
+		// Recursos podem ser herdados do nó pai; o nó é copiado para não alterar
+		// o objeto compartilhado do documento
+		if node["Resources"] == nil && inherited != nil {
+			copied := make(dict, len(node)+1)
+			for key, value := range node {
+				copied[key] = value
+			}
+			copied["Resources"] = inherited
+			node = copied
+		}
+		if kids, ok := doc.resolve(node["Kids"]).(array); ok {
+			for _, kid := range kids {
+				if r, ok := kid.(ref); ok {
+					if visited[r.n] {
+						continue
+					}
+					visited[r.n] = true
+				}
+				walk(doc.dictOf(kid), doc.dictOf(node["Resources"]), depth+1)
+			}
+		} else if node["Contents"] != nil {
+			pages = append(pages, node)
+		}
+	}
+	walk(root, nil, 0)
+
+	// Sem catálogo utilizável: usar todas as páginas encontradas
+	if len(pages) == 0 {
+		for _, num := range doc.numbers() {
+			if d, ok := doc.objects[num].value.(dict); ok && d["Type"] == name("Page") {
+				pages = append(pages, d)
+			}
+		}
+	}
+	return pages
+}
+
+// numbers retorna os números dos objetos do documento em ordem crescente
+// (percorrer de 0 ao maior número travaria com números enormes como 100000000000)
+func (doc *document) numbers() []int {
+	nums := make([]int, 0, len(doc.objects))
+	for num := range doc.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// extractor interpreta content streams e acumula o texto
+type extractor struct {
+	doc   *document
+	fonts map[int]*font
+	out   strings.Builder
+	lastY float64
+}
+
+// runContents processa os streams de conteúdo de uma página ou Form XObject
+func (e *extractor) runContents(contents interface{}, resources dict, depth int) {
+	e.run(e.contentData(contents, make(map[int]bool), 0), resources, depth)
+}
+
+// contentData concatena os streams de /Contents. Arrays referenciados são
+// percorridos com profundidade limitada e cada objeto é lido uma única vez, para
+// que um /Contents que referencia a si mesmo ("5 0 obj [5 0 R]") não estoure a pilha.
+func (e *extractor) contentData(contents interface{}, visited map[int]bool, depth int) []byte {
+	if depth > 64 {
+		return nil
+	}
+	var refs []interface{}
+	switch c := contents.(type) {
+	case array:
+		refs = c
+	default:
+		refs = []interface{}{c}
+	}
+
+	var data []byte
+	for _, r := range refs {
+		if rr, ok := r.(ref); ok {
+			if visited[rr.n] {
+				continue
+			}
+			visited[rr.n] = true
+		}
+		if resolved, ok := e.doc.resolve(r).(array); ok {
+			data = append(data, e.contentData(resolved, visited, depth+1)...)
+			continue
+		}
+		rr, ok := r.(ref)
+		if !ok {
+			continue
+		}
+		obj := e.doc.objects[rr.n]
+		if obj == nil || obj.stream == nil {
+			continue
+		}
+		decoded, err := e.doc.decodeStream(obj)
+		if err != nil {
+			continue
+		}
+		data = append(data, decoded...)
+		data = append(data, '\n')
+	}
+	return data
+}
+
+// run interpreta os operadores de texto de um content stream
+func (e *extractor) run(data []byte, resources dict, depth int) {
+	l := &lexer{data: data}
+	var operands []interface{}
+	var current *font
+
+	for {
+		tok := l.next()
+		if tok == nil {
+			return
+		}
+		kw, isKeyword := tok.(keyword)
+		if !isKeyword || kw == "[" || kw == "<<" {
+			operands = append(operands, l.build(tok))
+			continue
+		}
+
+		switch kw {
+		case "Tf":
+			if len(operands) >= 2 {
+				if n, ok := operands[len(operands)-2].(name); ok {
+					current = e.font(resources, string(n))
+				}
+			}
+		case "Tj":
+			e.show(current, lastOperand(operands))
+		case "'", "\"":
+			e.out.WriteString("\n")
+			e.show(current, lastOperand(operands))
+		case "TJ":
+			if items, ok := lastOperand(operands).(array); ok {
+				for _, item := range items {
+					if offset, ok := item.(float64); ok {
+						// Deslocamentos grandes representam espaços entre palavras
+						if offset < -200 {
+							e.out.WriteString(" ")
+						}
+						continue
+					}
+					e.show(current, item)
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && math.Abs(ty) > 0.1 {
+					e.out.WriteString("\n")
+				} else {
+					e.out.WriteString(" ")
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				if y, ok := operands[len(operands)-1].(float64); ok {
+					if math.Abs(y-e.lastY) > 0.1 {
+						e.out.WriteString("\n")
+					} else {
+						e.out.WriteString(" ")
+					}
+					e.lastY = y
+				}
+			}
+		case "T*", "ET":
+			e.out.WriteString("\n")
+		case "Do":
+			if n, ok := lastOperand(operands).(name); ok && depth < 8 {
+				e.runXObject(resources, string(n), depth)
+			}
+		case "ID":
+			// Imagem embutida: pular os dados binários até "EI"
+			if end := indexEI(data[l.pos:]); end >= 0 {
+				l.pos += end + 2
+			} else {
+				return
+			}
+		}
+		operands = nil
+	}
+}
+
+// eiRegex localiza o fim de uma imagem embutida
+var eiRegex = regexp.MustCompile(`\sEI(\s|$)`)
+
+// indexEI retorna a posição de "EI" após os dados de uma imagem embutida
+func indexEI(data []byte) int {
+	loc := eiRegex.FindIndex(data)
+	if loc == nil {
+		return -1
+	}
+	return loc[0] + 1
+}
+
+// lastOperand retorna o último operando (ou nil)
+func lastOperand(operands []interface{}) interface{} {
+	if len(operands) == 0 {
+		return nil
+	}
+	return operands[len(operands)-1]
+}
+
+// show escreve uma string de texto decodificada pela fonte corrente
+func (e *extractor) show(f *font, v interface{}) {
+	if s, ok := v.([]byte); ok {
+		e.out.WriteString(f.decode(s))
+	}
+}
+
+// font obtém (com cache) a fonte de nome informado nos recursos
+func (e *extractor) font(resources dict, fontName string) *font {
+	fonts := e.doc.dictOf(resources["Font"])
+	r, ok := fonts[fontName].(ref)
+	if !ok {
+		return nil
+	}
+	if f, cached := e.fonts[r.n]; cached {
+		return f
+	}
+
+	var f *font
+	fontDict := e.doc.dictOf(r)
+	if tu, ok := fontDict["ToUnicode"].(ref); ok {
+		if obj := e.doc.objects[tu.n]; obj != nil && obj.stream != nil {
+			if data, err := e.doc.decodeStream(obj); err == nil {
+				f = parseCMap(data)
+			}
+		}
+	}
+	e.fonts[r.n] = f
+	return f
+}
+
+// runXObject processa um Form XObject referenciado pelo operador Do
+func (e *extractor) runXObject(resources dict, xName string, depth int) {
+	xobjects := e.doc.dictOf(resources["XObject"])
+	r, ok := xobjects[xName].(ref)
+	if !ok {
+		return
+	}
+	obj := e.doc.objects[r.n]
+	if obj == nil || obj.stream == nil {
+		return
+	}
+	d, _ := obj.value.(dict)
+	if d["Subtype"] != name("Form") {
+		return
+	}
+
+	data, err := e.doc.decodeStream(obj)
+	if err != nil {
+		return
+	}
+
+	formResources := e.doc.dictOf(d["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	e.run(data, formResources, depth+1)
+}
+
+// spaceRegex normaliza espaços em cada linha
+var spaceRegex = regexp.MustCompile(`[ \t]+`)
+
+// cleanText remove linhas vazias e espaços repetidos
+func cleanText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(spaceRegex.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,193 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readFixture lê um PDF de testdata
+func readFixture(t testing.TB, file string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// buildPDF monta um PDF mínimo de uma página com o content stream e o dicionário
+// extra do stream informados (ex.: "/Filter /FlateDecode")
+func buildPDF(content []byte, streamDict string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	b.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	b.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>\nendobj\n")
+	fmt.Fprintf(&b, "4 0 obj\n<< %s >>\nstream\n", streamDict)
+	b.Write(content)
+	b.WriteString("\nendstream\nendobj\n%%EOF\n")
+	return b.Bytes()
+}
+
+// buildObjects monta um PDF com os objetos informados, numerados a partir de 1
+func buildObjects(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("%%EOF\n")
+	return b.Bytes()
+}
+
+// Conteúdo comum dos PDFs com ciclos
+const cyclicStream = "<< /Length 16 >>\nstream\nBT (ciclo) Tj ET\nendstream"
+
+// selfContentsPDF tem um /Contents que referencia a si mesmo
+var selfContentsPDF = buildObjects(
+	"<< /Type /Catalog /Pages 2 0 R >>",
+	"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+	"<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>",
+	cyclicStream,
+	"[5 0 R 4 0 R]",
+)
+
+// cyclicKidsPDF tem um nó /Pages que é filho de si mesmo, repetido
+var cyclicKidsPDF = buildObjects(
+	"<< /Type /Catalog /Pages 2 0 R >>",
+	"<< /Type /Pages /Kids [2 0 R 2 0 R 3 0 R] /Count 1 /Resources << /Font << >> >> >>",
+	"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+	cyclicStream,
+)
+
+func TestCyclicDocuments(t *testing.T) {
+	for name, data := range map[string][]byte{"contents": selfContentsPDF, "kids": cyclicKidsPDF} {
+		t.Run(name, func(t *testing.T) {
+			text, err := ExtractText(data)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if strings.Count(text, "ciclo") != 1 {
+				t.Errorf("texto %q: esperava \"ciclo\" uma única vez", text)
+			}
+		})
+	}
+}
+
+func TestInheritedResourcesNotShared(t *testing.T) {
+	doc, err := parseDocument(cyclicKidsPDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages := doc.pages()
+	if len(pages) != 1 || pages[0]["Resources"] == nil {
+		t.Fatalf("esperava uma página com os recursos herdados: %v", pages)
+	}
+	// O objeto da página no documento não deve ser alterado pela herança
+	if page := doc.objects[3].value.(dict); page["Resources"] != nil {
+		t.Error("os recursos herdados foram gravados no objeto compartilhado da página")
+	}
+}
+
+func TestExtractTextFixtures(t *testing.T) {
+	for _, file := range []string{"simple.pdf", "flate.pdf", "objstm.pdf"} {
+		t.Run(file, func(t *testing.T) {
+			text, err := ExtractText(readFixture(t, file))
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			for _, want := range []string{"Governo anuncia medidas", "Saúde pública"} {
+				if !strings.Contains(text, want) {
+					t.Errorf("texto %q não contém %q", text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTextNotPDF(t *testing.T) {
+	if _, err := ExtractText([]byte("<html>não é pdf</html>")); err == nil {
+		t.Error("esperava erro para arquivo que não é PDF")
+	}
+}
+
+func TestReadStreamHugeLength(t *testing.T) {
+	content := []byte("BT (texto) Tj ET")
+	for _, length := range []string{"1e30", "-5", "99999999999999999999"} {
+		data := buildPDF(content, "/Length "+length)
+		text, err := ExtractText(data)
+		if err != nil {
+			t.Fatalf("/Length %s: %v", length, err)
+		}
+		// Comprimento inválido: o stream é delimitado por "endstream"
+		if !strings.Contains(text, "texto") {
+			t.Errorf("/Length %s: texto %q", length, text)
+		}
+	}
+}
+
+func TestObjectStreamInvalidOffsets(t *testing.T) {
+	for _, tc := range []struct{ header, first string }{
+		{"1 0", "-10"},
+		{"1 -50", "4"},
+		{"1 0", "1e30"},
+		{"1 0", "2.5"},
+	} {
+		data := []byte(tc.header + " << /Type /Catalog >>")
+		pdf := fmt.Sprintf("%%PDF-1.5\n7 0 obj\n<< /Type /ObjStm /N 1 /First %s /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+			tc.first, len(data), data)
+		// Não deve entrar em pânico; o PDF não tem páginas
+		if _, err := ExtractText([]byte(pdf)); err == nil {
+			t.Errorf("/First %s, cabeçalho %q: esperava erro de PDF sem páginas", tc.first, tc.header)
+		}
+	}
+}
+
+func TestHugeObjectNumber(t *testing.T) {
+	// Sem árvore de páginas, as páginas são procuradas entre os objetos; um número
+	// de objeto enorme não deve fazer a busca percorrer todos os números até ele
+	data := []byte("%PDF-1.4\n100000000000 0 obj\n<< /Type /Font >>\nendobj\n")
+	if _, err := ExtractText(data); err == nil {
+		t.Error("esperava erro de PDF sem páginas")
+	}
+}
+
+func TestInflateLimit(t *testing.T) {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	zeros := make([]byte, 1<<20)
+	for i := 0; i < maxStreamSize>>20+1; i++ {
+		w.Write(zeros)
+	}
+	w.Close()
+
+	if _, err := inflate(compressed.Bytes()); err == nil {
+		t.Error("esperava erro para stream descomprimido acima do limite")
+	}
+
+	out, err := inflate(compressed.Bytes()[:1024])
+	if err != nil || len(out) == 0 || len(out) > maxStreamSize {
+		t.Errorf("stream truncado: %d bytes, erro %v", len(out), err)
+	}
+}
+
+func FuzzExtractText(f *testing.F) {
+	for _, file := range []string{"simple.pdf", "flate.pdf", "objstm.pdf"} {
+		f.Add(readFixture(f, file))
+	}
+	f.Add(buildPDF([]byte("BT (a) Tj ET"), "/Length 1e30"))
+	f.Add([]byte("%PDF-1.4\n100000000000 0 obj\n<< /Type /Font >>\nendobj\n"))
+	f.Add(selfContentsPDF)
+	f.Add(cyclicKidsPDF)
+	f.Add([]byte("%PDF-1.5\n1 0 obj\n<< /Type /ObjStm /N 1 /First -3 /Length 4 >>\nstream\n1 0 \nendstream\nendobj\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Qualquer entrada deve resultar em texto ou erro, nunca em pânico
+		ExtractText(data)
+	})
+}
@@ -0,0 +1,106 @@
+package reputation
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Palavras da lista de reputação que indicam fontes confiáveis e não confiáveis
+const (
+	Allow = "allow"
+	Deny  = "deny"
+)
+
+// Domain retorna o domínio de uma URL em minúsculas, sem "www." e sem porta;
+// aceita também um domínio sem esquema ("g1.globo.com"). Retorna "" se não houver domínio.
+func Domain(link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if !strings.Contains(host, ".") {
+		return "" // caminhos de arquivo e nomes sem domínio
+	}
+	return host
+}
+
+// parents retorna o domínio e os seus domínios pais, sem chegar a sufixos como
+// "com.br" (noticias.exemplo.com.br → exemplo.com.br)
+func parents(domain string) []string {
+	var domains []string
+	for domain != "" {
+		dots := strings.Count(domain, ".")
+		tld := domain[strings.LastIndex(domain, ".")+1:]
+		if dots == 0 || (dots == 1 && len(tld) == 2 && len(domains) > 0) {
+			break
+		}
+		domains = append(domains, domain)
+		domain = domain[strings.Index(domain, ".")+1:]
+	}
+	return domains
+}
+
+// List é uma lista de domínios informada pelo usuário, com o rótulo de cada domínio
+// (allow e deny são traduzidos para os rótulos confiável e não confiável)
+type List struct {
+	Labels map[string]string
+}
+
+// LoadList lê uma lista de reputação: uma entrada "<allow|deny|rótulo> <domínio ou URL>"
+// por linha; linhas vazias e iniciadas por # são ignoradas
+func LoadList(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list := &List{Labels: make(map[string]string)}
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: use \"<allow|deny|rótulo> <domínio>\"", path, line)
+		}
+		domain := Domain(fields[1])
+		if domain == "" {
+			return nil, fmt.Errorf("%s:%d: domínio inválido: %s", path, line, fields[1])
+		}
+		list.Labels[domain] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Lookup retorna a entrada da lista para o domínio (ou para o domínio pai mais
+// próximo) e o domínio encontrado; "" se o domínio não estiver na lista
+func (l *List) Lookup(domain string) (entry string, matched string) {
+	if l == nil {
+		return "", ""
+	}
+	for _, candidate := range parents(domain) {
+		if entry, ok := l.Labels[candidate]; ok {
+			return entry, candidate
+		}
+	}
+	return "", ""
+}
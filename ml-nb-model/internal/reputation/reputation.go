@@ -0,0 +1,255 @@
+package reputation
+
+import (
+	"math"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Options configura o prior de fonte
+type Options struct {
+	Alpha        float64 // pseudo-contagens da distribuição geral das classes em cada domínio
+	Weight       float64 // expoente do prior na combinação com o modelo de texto (0 = sem efeito)
+	ListStrength float64 // probabilidade (0-1) do rótulo de um domínio da lista do usuário
+	Trusted      string  // rótulo das fontes "allow"
+	Untrusted    string  // rótulo das fontes "deny"
+}
+
+// DefaultOptions retorna as opções padrão do prior de fonte
+func DefaultOptions() Options {
+	return Options{Alpha: 5, Weight: 1, ListStrength: 0.9, Trusted: "true", Untrusted: "fake"}
+}
+
+// Model estima P(classe | domínio) pelos rótulos das notícias de treinamento de cada
+// domínio, suavizados pela distribuição geral das classes, e pela lista do usuário
+type Model struct {
+	Options
+	List    *List
+	Classes []string
+	Base    map[string]float64        // proporção de cada classe no treinamento
+	Domains map[string]map[string]int // rótulos das notícias de cada domínio
+}
+
+// New cria o modelo de reputação (list pode ser nil)
+func New(opts Options, list *List) *Model {
+	return &Model{Options: opts, List: list}
+}
+
+// Train conta os rótulos por domínio (do link do documento ou, sem link, da fonte)
+func (m *Model) Train(docs []models.Document) {
+	m.Domains = make(map[string]map[string]int)
+	counts := make(map[string]int)
+	total := 0
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.Text) == "" || doc.Label == "" {
+			continue
+		}
+		counts[doc.Label]++
+		total++
+		domain := DocumentDomain(doc)
+		if domain == "" {
+			continue
+		}
+		if m.Domains[domain] == nil {
+			m.Domains[domain] = make(map[string]int)
+		}
+		m.Domains[domain][doc.Label]++
+	}
+
+	m.Classes = models.Labels(docs)
+	m.Base = make(map[string]float64)
+	for _, class := range m.Classes {
+		// Sem documentos com texto, o prior base é uniforme
+		if total == 0 {
+			m.Base[class] = 1 / float64(len(m.Classes))
+			continue
+		}
+		m.Base[class] = float64(counts[class]) / float64(total)
+	}
+}
+
+// DocumentDomain retorna o domínio do link do documento ou, sem link, da sua fonte
+func DocumentDomain(doc models.Document) string {
+	if domain := Domain(doc.URL); domain != "" {
+		return domain
+	}
+	return Domain(doc.Source)
+}
+
+// Prior é a evidência da fonte de uma notícia
+type Prior struct {
+	Domain        string
+	Matched       string             // domínio (ou domínio pai) com evidência; "" se desconhecido
+	Listed        string             // entrada da lista do usuário que decidiu o prior ("" se não listado)
+	Counts        map[string]int     // rótulos das notícias de treinamento do domínio
+	Total         int                // notícias de treinamento do domínio
+	Probabilities map[string]float64 // P(classe | fonte), de 0 a 1
+	LogRatios     map[string]float64 // log(P(classe | fonte) / P(classe)); 0 sem evidência
+}
+
+// Known indica se há evidência sobre a fonte (na lista ou no treinamento)
+func (p Prior) Known() bool {
+	return p.Matched != ""
+}
+
+// Prior retorna a evidência do domínio do link: a lista do usuário tem precedência
+// sobre as contagens do treinamento, e domínios sem evidência ficam com a
+// distribuição geral das classes
+func (m *Model) Prior(link string) Prior {
+	prior := Prior{
+		Domain:        Domain(link),
+		Probabilities: make(map[string]float64),
+		LogRatios:     make(map[string]float64),
+	}
+	for class, p := range m.Base {
+		prior.Probabilities[class] = p
+	}
+	if prior.Domain == "" {
+		return prior
+	}
+
+	if entry, matched := m.List.Lookup(prior.Domain); entry != "" {
+		if label := m.listLabel(entry); m.Base[label] > 0 && m.Base[label] < 1 {
+			prior.Matched, prior.Listed = matched, entry
+			for _, class := range m.Classes {
+				if class == label {
+					prior.Probabilities[class] = m.ListStrength
+				} else {
+					prior.Probabilities[class] = (1 - m.ListStrength) * m.Base[class] / (1 - m.Base[label])
+				}
+			}
+		}
+	}
+
+	if !prior.Known() {
+		for _, candidate := range parents(prior.Domain) {
+			counts := m.Domains[candidate]
+			if counts == nil {
+				continue
+			}
+			prior.Matched, prior.Counts = candidate, counts
+			for _, n := range counts {
+				prior.Total += n
+			}
+			for _, class := range m.Classes {
+				prior.Probabilities[class] = (float64(counts[class]) + m.Alpha*m.Base[class]) / (float64(prior.Total) + m.Alpha)
+			}
+			break
+		}
+	}
+
+	for _, class := range m.Classes {
+		if prior.Probabilities[class] > 0 && m.Base[class] > 0 {
+			prior.LogRatios[class] = math.Log(prior.Probabilities[class] / m.Base[class])
+		}
+	}
+	return prior
+}
+
+// listLabel traduz uma entrada da lista para o rótulo correspondente
+func (m *Model) listLabel(entry string) string {
+	switch entry {
+	case Allow:
+		return m.Trusted
+	case Deny:
+		return m.Untrusted
+	}
+	return entry
+}
+
+// SourceToken é o nome usado para a fonte nas contribuições dos classificadores
+func SourceToken(domain string) string {
+	return "[fonte] " + domain
+}
+
+// Combine aplica o prior da fonte às probabilidades do modelo de texto pela regra de
+// Bayes, P(c | texto, fonte) ∝ P(c | texto) · [P(c | fonte) / P(c)]^Weight, e acrescenta
+// às contribuições o efeito da fonte, em pontos percentuais de cada classe
+func (m *Model) Combine(result models.ClassificationResult, prior Prior) models.ClassificationResult {
+	if !prior.Known() || m.Weight == 0 {
+		return result
+	}
+
+	combined := make(map[string]float64)
+	sum := 0.0
+	for class, p := range result.Probabilities {
+		combined[class] = p * math.Exp(m.Weight*prior.LogRatios[class])
+		sum += combined[class]
+	}
+	if sum == 0 {
+		return result
+	}
+
+	effect := models.TokenContribution{Token: SourceToken(prior.Matched), Count: 1, Scores: make(map[string]float64)}
+	result.Label, result.Confidence = "", -1
+	for class := range combined {
+		combined[class] *= 100 / sum
+		effect.Scores[class] = combined[class] - result.Probabilities[class]
+		if combined[class] > result.Confidence || (combined[class] == result.Confidence && class < result.Label) {
+			result.Label, result.Confidence = class, combined[class]
+		}
+	}
+	result.Probabilities = combined
+	result.Contributions = append([]models.TokenContribution{effect}, result.Contributions...)
+	return result
+}
+
+// Predict retorna o prior da fonte como uma classificação (probabilidades de 0 a 100),
+// para avaliar a fonte sozinha
+func (m *Model) Predict(prior Prior) models.ClassificationResult {
+	result := models.ClassificationResult{Probabilities: make(map[string]float64), Confidence: -1}
+	for _, class := range m.Classes {
+		result.Probabilities[class] = prior.Probabilities[class] * 100
+		if result.Probabilities[class] > result.Confidence {
+			result.Label, result.Confidence = class, result.Probabilities[class]
+		}
+	}
+	if prior.Known() {
+		result.Contributions = []models.TokenContribution{{Token: SourceToken(prior.Matched), Count: 1, Scores: prior.LogRatios}}
+	}
+	return result
+}
+
+// CrossPriors retorna, para cada predição out-of-fold (de evaluation.CrossValidate), o
+// modelo de reputação treinado apenas com os documentos de treinamento do seu fold
+// e o prior da fonte do documento previsto
+func CrossPriors(docs []models.Document, predictions []evaluation.Prediction, numFolds int, opts Options, list *List) ([]*Model, []Prior) {
+	assignment := evaluation.AssignFolds(docs, numFolds)
+	byID := make(map[string]models.Document)
+	for _, doc := range docs {
+		byID[doc.ID] = doc
+	}
+
+	folds := make([]*Model, numFolds)
+	for fold := range folds {
+		var train []models.Document
+		for i, doc := range docs {
+			if assignment[i] != fold {
+				train = append(train, doc)
+			}
+		}
+		folds[fold] = New(opts, list)
+		folds[fold].Train(train)
+	}
+
+	sources := make([]*Model, len(predictions))
+	priors := make([]Prior, len(predictions))
+	for i, pred := range predictions {
+		sources[i] = folds[pred.Fold]
+		priors[i] = sources[i].Prior(DocumentDomain(byID[pred.ID]))
+	}
+	return sources, priors
+}
+
+// CrossApply combina cada predição out-of-fold com o prior da fonte do seu fold (ver CrossPriors)
+func CrossApply(docs []models.Document, predictions []evaluation.Prediction, numFolds int, opts Options, list *List) []evaluation.Prediction {
+	sources, priors := CrossPriors(docs, predictions, numFolds, opts, list)
+	combined := make([]evaluation.Prediction, len(predictions))
+	for i, pred := range predictions {
+		combined[i] = pred
+		combined[i].Result = sources[i].Combine(pred.Result, priors[i])
+	}
+	return combined
+}
@@ -0,0 +1,405 @@
+package retrieval
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Funções de pontuação do índice
+const (
+	ScoringTFIDF = "tfidf" // similaridade de cosseno entre vetores TF-IDF
+	ScoringBM25  = "bm25"  // Okapi BM25
+)
+
+// Scorings lista as funções de pontuação disponíveis
+var Scorings = []string{ScoringTFIDF, ScoringBM25}
+
+// Options configura o índice
+type Options struct {
+	Scoring string
+	K1      float64 // saturação da frequência do termo no BM25
+	B       float64 // normalização pelo tamanho do documento no BM25
+}
+
+// DefaultOptions retorna a configuração padrão: TF-IDF com os parâmetros usuais do BM25
+func DefaultOptions() Options {
+	return Options{Scoring: ScoringTFIDF, K1: 1.2, B: 0.75}
+}
+
+// posting é a ocorrência de um termo em um documento
+type posting struct {
+	doc int
+	tf  float64
+}
+
+// Index é um índice invertido sobre os documentos do corpus
+type Index struct {
+	Options
+	Docs     []models.Document
+	terms    map[string]int
+	postings [][]posting // [termo] -> documentos que o contêm
+	idf      []float64
+	lengths  []float64 // número de tokens de cada documento
+	norms    []float64 // norma do vetor TF-IDF de cada documento
+	avgLen   float64
+}
+
+// Hit é um documento do corpus semelhante à consulta
+type Hit struct {
+	Index    int // posição do documento em Index.Docs
+	Document models.Document
+	Score    float64
+	Terms    []string // termos em comum que mais contribuíram para a pontuação
+	// Contributions é a parcela da pontuação devida a cada termo em comum
+	Contributions map[string]float64
+}
+
+// NewIndex indexa o texto dos documentos (documentos sem texto ou rótulo são ignorados)
+func NewIndex(docs []models.Document, opts Options) (*Index, error) {
+	if opts.Scoring != ScoringTFIDF && opts.Scoring != ScoringBM25 {
+		return nil, fmt.Errorf("pontuação desconhecida: %s (use tfidf ou bm25)", opts.Scoring)
+	}
+
+	ix := &Index{Options: opts, terms: make(map[string]int)}
+	for _, doc := range docs {
+		texts, _ := models.Samples([]models.Document{doc})
+		if len(texts) == 0 {
+			continue
+		}
+
+		index := len(ix.Docs)
+		ix.Docs = append(ix.Docs, doc)
+		counts := termCounts(doc.Text)
+		length := 0.0
+		for _, term := range sortedTerms(counts) {
+			id, ok := ix.terms[term]
+			if !ok {
+				id = len(ix.postings)
+				ix.terms[term] = id
+				ix.postings = append(ix.postings, nil)
+			}
+			ix.postings[id] = append(ix.postings[id], posting{doc: index, tf: counts[term]})
+			length += counts[term]
+		}
+		ix.lengths = append(ix.lengths, length)
+		ix.avgLen += length
+	}
+	if len(ix.Docs) > 0 {
+		ix.avgLen /= float64(len(ix.Docs))
+	}
+
+	n := float64(len(ix.Docs))
+	ix.idf = make([]float64, len(ix.postings))
+	ix.norms = make([]float64, len(ix.Docs))
+	for id, postings := range ix.postings {
+		df := float64(len(postings))
+		if opts.Scoring == ScoringBM25 {
+			ix.idf[id] = math.Log(1 + (n-df+0.5)/(df+0.5))
+		} else {
+			ix.idf[id] = math.Log((n+1)/(df+1)) + 1
+		}
+		for _, p := range postings {
+			w := tfWeight(p.tf) * ix.idf[id]
+			ix.norms[p.doc] += w * w
+		}
+	}
+	for i := range ix.norms {
+		ix.norms[i] = math.Sqrt(ix.norms[i])
+	}
+
+	return ix, nil
+}
+
+// Len retorna o número de documentos indexados
+func (ix *Index) Len() int {
+	return len(ix.Docs)
+}
+
+// queryTerm é um termo da consulta presente no índice
+type queryTerm struct {
+	term   string
+	id     int
+	weight float64 // peso TF-IDF do termo na consulta
+}
+
+// Search retorna os k documentos mais semelhantes ao texto, da maior para a
+// menor pontuação; filter (opcional) restringe os documentos considerados. As
+// pontuações são acumuladas termo a termo e só os k melhores documentos (mantidos
+// em um heap) recebem as contribuições de cada termo.
+func (ix *Index) Search(text string, k int, filter func(models.Document) bool) []Hit {
+	query, scores, candidates := ix.score(text, filter)
+	if k <= 0 || k > len(candidates) {
+		k = len(candidates)
+	}
+	best := &hitHeap{scores: scores}
+	for _, doc := range candidates {
+		if best.Len() < k {
+			heap.Push(best, doc)
+		} else if best.better(doc, best.docs[0]) {
+			best.docs[0] = doc
+			heap.Fix(best, 0)
+		}
+	}
+
+	hits := make([]Hit, best.Len())
+	for i := len(hits) - 1; i >= 0; i-- {
+		hits[i] = ix.hit(query, heap.Pop(best).(int), scores)
+	}
+	return hits
+}
+
+// score acumula a pontuação de cada documento com termos em comum com o texto
+// (normalizada pelo cosseno no TF-IDF) e retorna os termos da consulta, as
+// pontuações por documento e os documentos com pontuação positiva
+func (ix *Index) score(text string, filter func(models.Document) bool) ([]queryTerm, []float64, []int) {
+	counts := termCounts(text)
+	var query []queryTerm
+	queryNorm := 0.0
+	for _, term := range sortedTerms(counts) {
+		if id, ok := ix.terms[term]; ok {
+			weight := tfWeight(counts[term]) * ix.idf[id]
+			query = append(query, queryTerm{term: term, id: id, weight: weight})
+			queryNorm += weight * weight
+		}
+	}
+	queryNorm = math.Sqrt(queryNorm)
+
+	scores := make([]float64, len(ix.Docs))
+	var candidates []int
+	allowed := make(map[int]bool)
+	for _, q := range query {
+		for _, p := range ix.postings[q.id] {
+			if filter != nil {
+				ok, seen := allowed[p.doc]
+				if !seen {
+					ok = filter(ix.Docs[p.doc])
+					allowed[p.doc] = ok
+				}
+				if !ok {
+					continue
+				}
+			}
+			if scores[p.doc] == 0 {
+				candidates = append(candidates, p.doc)
+			}
+			scores[p.doc] += ix.termScore(q, p)
+		}
+	}
+
+	if ix.Scoring == ScoringTFIDF {
+		kept := candidates[:0]
+		for _, doc := range candidates {
+			if queryNorm == 0 || ix.norms[doc] == 0 {
+				continue
+			}
+			scores[doc] /= queryNorm * ix.norms[doc]
+			kept = append(kept, doc)
+		}
+		candidates = kept
+	}
+	return query, scores, candidates
+}
+
+// termScore é a parcela da pontuação de um documento devida a um termo da consulta
+// (sem a normalização pelo cosseno do TF-IDF)
+func (ix *Index) termScore(q queryTerm, p posting) float64 {
+	if ix.Scoring == ScoringBM25 {
+		norm := 1 - ix.B + ix.B*ix.lengths[p.doc]/ix.avgLen
+		return ix.idf[q.id] * p.tf * (ix.K1 + 1) / (p.tf + ix.K1*norm)
+	}
+	return q.weight * tfWeight(p.tf) * ix.idf[q.id]
+}
+
+// hit monta o resultado de um documento com a contribuição de cada termo em comum
+func (ix *Index) hit(query []queryTerm, doc int, scores []float64) Hit {
+	scale := 1.0
+	if ix.Scoring == ScoringTFIDF {
+		queryNorm := 0.0
+		for _, q := range query {
+			queryNorm += q.weight * q.weight
+		}
+		scale = math.Sqrt(queryNorm) * ix.norms[doc]
+	}
+
+	contributions := make(map[string]float64)
+	for _, q := range query {
+		// As ocorrências de cada termo estão em ordem crescente de documento
+		postings := ix.postings[q.id]
+		i := sort.Search(len(postings), func(i int) bool { return postings[i].doc >= doc })
+		if i < len(postings) && postings[i].doc == doc {
+			contributions[q.term] = ix.termScore(q, postings[i]) / scale
+		}
+	}
+	return Hit{
+		Index:         doc,
+		Document:      ix.Docs[doc],
+		Score:         scores[doc],
+		Terms:         topTerms(contributions, 5),
+		Contributions: contributions,
+	}
+}
+
+// hitHeap é um heap mínimo de documentos pela pontuação (o pior no topo), usado
+// para manter os k melhores sem ordenar todos os candidatos
+type hitHeap struct {
+	docs   []int
+	scores []float64
+}
+
+// better indica se o documento a vem antes de b no ranking (desempate pela posição)
+func (h *hitHeap) better(a, b int) bool {
+	if h.scores[a] != h.scores[b] {
+		return h.scores[a] > h.scores[b]
+	}
+	return a < b
+}
+
+func (h *hitHeap) Len() int           { return len(h.docs) }
+func (h *hitHeap) Less(i, j int) bool { return h.better(h.docs[j], h.docs[i]) }
+func (h *hitHeap) Swap(i, j int)      { h.docs[i], h.docs[j] = h.docs[j], h.docs[i] }
+func (h *hitHeap) Push(x interface{}) { h.docs = append(h.docs, x.(int)) }
+func (h *hitHeap) Pop() interface{} {
+	last := h.docs[len(h.docs)-1]
+	h.docs = h.docs[:len(h.docs)-1]
+	return last
+}
+
+// Vector retorna o vetor TF-IDF de norma unitária de um texto, indexado pelos
+// termos do índice (termos fora do índice são ignorados)
+func (ix *Index) Vector(text string) map[string]float64 {
+	vector := make(map[string]float64)
+	norm := 0.0
+	for term, tf := range termCounts(text) {
+		if id, ok := ix.terms[term]; ok {
+			vector[term] = tfWeight(tf) * ix.idf[id]
+			norm += vector[term] * vector[term]
+		}
+	}
+	return normalize(vector, norm)
+}
+
+// DocumentVectors retorna o vetor TF-IDF de norma unitária de cada documento indexado
+func (ix *Index) DocumentVectors() []map[string]float64 {
+	vectors := make([]map[string]float64, len(ix.Docs))
+	for i := range vectors {
+		vectors[i] = make(map[string]float64)
+	}
+	for term, id := range ix.terms {
+		for _, p := range ix.postings[id] {
+			if ix.norms[p.doc] > 0 {
+				vectors[p.doc][term] = tfWeight(p.tf) * ix.idf[id] / ix.norms[p.doc]
+			}
+		}
+	}
+	return vectors
+}
+
+// normalize divide o vetor pela raiz de norm (a soma dos quadrados)
+func normalize(vector map[string]float64, norm float64) map[string]float64 {
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+	}
+	return vector
+}
+
+// SearchByLabel retorna os k documentos mais semelhantes de cada rótulo do corpus,
+// com um heap dos k melhores por rótulo
+func (ix *Index) SearchByLabel(text string, k int) map[string][]Hit {
+	query, scores, candidates := ix.score(text, nil)
+	heaps := make(map[string]*hitHeap)
+	for _, doc := range candidates {
+		label := ix.Docs[doc].Label
+		best := heaps[label]
+		if best == nil {
+			best = &hitHeap{scores: scores}
+			heaps[label] = best
+		}
+		if best.Len() < k {
+			heap.Push(best, doc)
+		} else if k > 0 && best.better(doc, best.docs[0]) {
+			best.docs[0] = doc
+			heap.Fix(best, 0)
+		}
+	}
+
+	byLabel := make(map[string][]Hit)
+	for label, best := range heaps {
+		if best.Len() == 0 {
+			continue
+		}
+		hits := make([]Hit, best.Len())
+		for i := len(hits) - 1; i >= 0; i-- {
+			hits[i] = ix.hit(query, heap.Pop(best).(int), scores)
+		}
+		byLabel[label] = hits
+	}
+	return byLabel
+}
+
+// termCounts conta os tokens pré-processados do texto
+func termCounts(text string) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, token := range utils.PreprocessText(text) {
+		counts[token]++
+	}
+	return counts
+}
+
+// sortedTerms retorna os termos em ordem alfabética (indexação determinística)
+func sortedTerms(counts map[string]float64) []string {
+	terms := make([]string, 0, len(counts))
+	for term := range counts {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+// tfWeight é o peso sublinear da frequência do termo no TF-IDF
+func tfWeight(tf float64) float64 {
+	return 1 + math.Log(tf)
+}
+
+// topTerms retorna os n termos de maior contribuição (desempate alfabético)
+func topTerms(contributions map[string]float64, n int) []string {
+	terms := sortedTerms(contributions)
+	sort.SliceStable(terms, func(i, j int) bool {
+		return contributions[terms[i]] > contributions[terms[j]]
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+// Evidence é a forma serializável de um Hit, usada como evidência de apoio à classificação
+type Evidence struct {
+	ID     string   `json:"id"`
+	Label  string   `json:"label"`
+	Title  string   `json:"title,omitempty"`
+	URL    string   `json:"url,omitempty"`
+	Source string   `json:"source,omitempty"`
+	Score  float64  `json:"score"`
+	Terms  []string `json:"terms,omitempty"`
+}
+
+// Evidence converte o Hit em evidência serializável
+func (h Hit) Evidence() Evidence {
+	return Evidence{
+		ID:     h.Document.ID,
+		Label:  h.Document.Label,
+		Title:  h.Document.Title,
+		URL:    h.Document.URL,
+		Source: h.Document.Source,
+		Score:  h.Score,
+		Terms:  h.Terms,
+	}
+}
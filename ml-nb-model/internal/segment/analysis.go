@@ -0,0 +1,137 @@
+package segment
+
+import (
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Options configura a análise segmentada
+type Options struct {
+	Level     string // LevelParagraph ou LevelSentence
+	MinTokens int    // trechos com menos tokens úteis não são pontuados
+	Target    string // classe cujos trechos responsáveis são destacados (ex.: "fake")
+}
+
+// DefaultOptions retorna a configuração padrão (sentenças, destaque para "fake")
+func DefaultOptions() Options {
+	return Options{
+		Level:     LevelSentence,
+		MinTokens: 3,
+		Target:    "fake",
+	}
+}
+
+// SegmentResult representa a classificação de um trecho
+type SegmentResult struct {
+	Segment
+	Tokens    int                         // número de tokens após o pré-processamento
+	Scored    bool                        // falso para trechos curtos demais
+	Result    models.ClassificationResult // classificação do trecho
+	Influence float64                     // contribuição ponderada para a classe alvo (positivo = a favor)
+}
+
+// Analysis representa a análise segmentada de um documento
+type Analysis struct {
+	Text          string
+	Options       Options
+	Segments      []SegmentResult
+	Label         string                      // veredito agregado
+	Confidence    float64                     // probabilidade agregada do veredito (0-100)
+	Probabilities map[string]float64          // probabilidades agregadas por classe (0-100)
+	Document      models.ClassificationResult // classificação do texto inteiro, para comparação
+}
+
+// Analyze divide o texto em trechos, classifica cada um e agrega o resultado.
+// As probabilidades do documento são a média das probabilidades dos trechos,
+// ponderada pelo número de tokens de cada trecho.
+func Analyze(classifier models.Classifier, text string, opts Options) *Analysis {
+	analysis := &Analysis{
+		Text:          text,
+		Options:       opts,
+		Probabilities: make(map[string]float64),
+		Document:      classifier.Predict(text),
+	}
+
+	totalTokens := 0
+	for _, segment := range Split(text, opts.Level) {
+		result := SegmentResult{
+			Segment: segment,
+			Tokens:  len(utils.PreprocessText(segment.Text)),
+		}
+		if result.Tokens >= opts.MinTokens {
+			result.Scored = true
+			result.Result = classifier.Predict(segment.Text)
+			totalTokens += result.Tokens
+		}
+		analysis.Segments = append(analysis.Segments, result)
+	}
+
+	// Sem trechos pontuáveis: usar a classificação do texto inteiro
+	if totalTokens == 0 {
+		analysis.Label = analysis.Document.Label
+		analysis.Confidence = analysis.Document.Confidence
+		analysis.Probabilities = analysis.Document.Probabilities
+		return analysis
+	}
+
+	for i := range analysis.Segments {
+		segment := &analysis.Segments[i]
+		if !segment.Scored {
+			continue
+		}
+		weight := float64(segment.Tokens) / float64(totalTokens)
+		for label, prob := range segment.Result.Probabilities {
+			analysis.Probabilities[label] += weight * prob
+		}
+	}
+
+	uniform := 100.0 / float64(len(analysis.Probabilities))
+	for i := range analysis.Segments {
+		segment := &analysis.Segments[i]
+		if !segment.Scored {
+			continue
+		}
+		weight := float64(segment.Tokens) / float64(totalTokens)
+		segment.Influence = weight * (segment.Result.Probabilities[opts.Target] - uniform)
+	}
+
+	for _, label := range sortedLabels(analysis.Probabilities) {
+		if prob := analysis.Probabilities[label]; analysis.Label == "" || prob > analysis.Confidence {
+			analysis.Label = label
+			analysis.Confidence = prob
+		}
+	}
+
+	return analysis
+}
+
+// Drivers retorna os n trechos que mais contribuíram para a classe alvo
+func (a *Analysis) Drivers(n int) []SegmentResult {
+	var drivers []SegmentResult
+	for _, segment := range a.Segments {
+		if segment.Scored && segment.Influence > 0 {
+			drivers = append(drivers, segment)
+		}
+	}
+
+	sort.Slice(drivers, func(i, j int) bool {
+		return drivers[i].Influence > drivers[j].Influence
+	})
+
+	if len(drivers) > n {
+		drivers = drivers[:n]
+	}
+	return drivers
+}
+
+// sortedLabels retorna as classes em ordem alfabética
+func sortedLabels(probs map[string]float64) []string {
+	labels := make([]string, 0, len(probs))
+	for label := range probs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
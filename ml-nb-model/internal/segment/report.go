@@ -0,0 +1,148 @@
+package segment
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// reportTemplate é o modelo do relatório HTML com os trechos destacados
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Análise segmentada - {{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 960px; margin: 2em auto; color: #222; line-height: 1.6; }
+h1, h2, table { font-family: Helvetica, Arial, sans-serif; }
+h1 { font-size: 1.4em; }
+.summary td { padding: 2px 12px 2px 0; }
+.text p { margin: 0 0 1em 0; }
+.seg { border-radius: 3px; padding: 1px 0; }
+.seg.unscored { color: #777; }
+.drivers { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+.drivers th, .drivers td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+.legend span { padding: 2px 8px; margin-right: 8px; border-radius: 3px; }
+</style>
+</head>
+<body>
+<h1>Análise segmentada</h1>
+<table class="summary">
+<tr><td>Fonte</td><td>{{.Title}}</td></tr>
+<tr><td>Veredito agregado</td><td><strong>{{.Label}}</strong> ({{printf "%.2f" .Confidence}}%)</td></tr>
+<tr><td>Probabilidades agregadas</td><td>{{range .Probabilities}}{{.Label}}: {{printf "%.1f" .Value}}% &nbsp; {{end}}</td></tr>
+<tr><td>Texto inteiro</td><td>{{.DocumentLabel}} ({{printf "%.2f" .DocumentConfidence}}%)</td></tr>
+<tr><td>Segmentação</td><td>{{.Level}} ({{len .Paragraphs}} parágrafos, {{.Scored}} trechos pontuados)</td></tr>
+</table>
+<p class="legend"><span style="background: rgba(220,40,40,0.45)">a favor de "{{.Target}}"</span><span style="background: rgba(40,160,70,0.45)">contra "{{.Target}}"</span><span style="color:#777">não pontuado</span></p>
+
+<h2>Trechos que mais contribuíram para "{{.Target}}"</h2>
+{{if .Drivers}}<table class="drivers">
+<tr><th>#</th><th>Trecho</th><th>P({{.Target}})</th><th>Influência</th></tr>
+{{range .Drivers}}<tr><td>{{.Index}}</td><td>{{.Text}}</td><td>{{printf "%.1f" .Prob}}%</td><td>{{printf "%+.2f" .Influence}}</td></tr>
+{{end}}</table>{{else}}<p>Nenhum trecho favorece "{{.Target}}".</p>{{end}}
+
+<h2>Texto</h2>
+<div class="text">
+{{range .Paragraphs}}<p>{{range .}}<span class="seg{{if not .Scored}} unscored{{end}}" style="{{.Style}}" title="{{.Tooltip}}">{{.Text}}</span> {{end}}</p>
+{{end}}</div>
+</body>
+</html>
+`))
+
+// reportSegment é um trecho preparado para o template
+type reportSegment struct {
+	Index     int
+	Text      string
+	Scored    bool
+	Prob      float64
+	Influence float64
+	Style     template.CSS
+	Tooltip   string
+}
+
+// reportProb é uma probabilidade agregada preparada para o template
+type reportProb struct {
+	Label string
+	Value float64
+}
+
+// WriteHTML gera o relatório HTML da análise com os trechos destacados
+// conforme a probabilidade da classe alvo
+func (a *Analysis) WriteHTML(w io.Writer, title string) error {
+	target := a.Options.Target
+	// Com k classes, o trecho é neutro quando P(alvo) = 100/k, como na influência
+	uniform := 100.0
+	if len(a.Probabilities) > 0 {
+		uniform /= float64(len(a.Probabilities))
+	}
+
+	var paragraphs [][]reportSegment
+	scored := 0
+	for _, segment := range a.Segments {
+		for len(paragraphs) <= segment.Paragraph {
+			paragraphs = append(paragraphs, nil)
+		}
+
+		item := reportSegment{
+			Index:  segment.Index,
+			Text:   segment.Text,
+			Scored: segment.Scored,
+		}
+		if segment.Scored {
+			scored++
+			item.Prob = segment.Result.Probabilities[target]
+			item.Influence = segment.Influence
+			item.Style = highlightStyle(item.Prob, uniform)
+			item.Tooltip = fmt.Sprintf("#%d · %s · P(%s) = %.1f%% · %d tokens",
+				segment.Index, segment.Result.Label, target, item.Prob, segment.Tokens)
+		} else {
+			item.Tooltip = fmt.Sprintf("#%d · trecho curto demais (%d tokens)", segment.Index, segment.Tokens)
+		}
+		paragraphs[segment.Paragraph] = append(paragraphs[segment.Paragraph], item)
+	}
+
+	var drivers []reportSegment
+	for _, segment := range a.Drivers(10) {
+		drivers = append(drivers, reportSegment{
+			Index:     segment.Index,
+			Text:      segment.Text,
+			Prob:      segment.Result.Probabilities[target],
+			Influence: segment.Influence,
+		})
+	}
+
+	var probs []reportProb
+	for _, label := range sortedLabels(a.Probabilities) {
+		probs = append(probs, reportProb{label, a.Probabilities[label]})
+	}
+
+	return reportTemplate.Execute(w, map[string]interface{}{
+		"Title":              title,
+		"Label":              a.Label,
+		"Confidence":         a.Confidence,
+		"Probabilities":      probs,
+		"DocumentLabel":      a.Document.Label,
+		"DocumentConfidence": a.Document.Confidence,
+		"Level":              a.Options.Level,
+		"Target":             target,
+		"Paragraphs":         paragraphs,
+		"Scored":             scored,
+		"Drivers":            drivers,
+	})
+}
+
+// highlightStyle retorna a cor de fundo do trecho: vermelho quando favorece a classe
+// alvo (acima da probabilidade uniforme 100/k), verde quando a desfavorece, com
+// intensidade proporcional à distância até 100% ou 0%
+func highlightStyle(prob, uniform float64) template.CSS {
+	switch {
+	case prob > uniform:
+		strength := (prob - uniform) / (100 - uniform) * 0.6
+		return template.CSS(fmt.Sprintf("background: rgba(220,40,40,%.2f)", strength))
+	case prob < uniform:
+		strength := (uniform - prob) / uniform * 0.6
+		return template.CSS(fmt.Sprintf("background: rgba(40,160,70,%.2f)", strength))
+	}
+	return ""
+}
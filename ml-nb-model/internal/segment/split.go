@@ -0,0 +1,142 @@
+package segment
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Níveis de segmentação suportados
+const (
+	LevelParagraph = "paragraph"
+	LevelSentence  = "sentence"
+)
+
+// Segment representa um trecho do texto original
+type Segment struct {
+	Index     int
+	Paragraph int    // índice do parágrafo ao qual o trecho pertence
+	Start     int    // posição inicial (em bytes) no texto original
+	End       int    // posição final (exclusiva) no texto original
+	Text      string // texto do trecho
+}
+
+// paragraphRegex separa parágrafos por linhas em branco
+var paragraphRegex = regexp.MustCompile(`\n\s*\n`)
+
+// sentenceEndRegex localiza possíveis fins de sentença
+var sentenceEndRegex = regexp.MustCompile(`[.!?…]+["'”’)]*\s+`)
+
+// abbreviations são abreviações comuns que não encerram sentenças
+var abbreviations = map[string]bool{
+	"sr": true, "sra": true, "srta": true, "dr": true, "dra": true, "prof": true, "profa": true,
+	"art": true, "arts": true, "inc": true, "ltda": true, "etc": true, "ex": true, "p": true,
+	"pág": true, "núm": true, "n": true, "nº": true, "av": true, "gov": true, "dep": true,
+	"sen": true, "min": true, "pres": true, "gen": true, "cel": true, "cap": true, "jan": true,
+	"fev": true, "mar": true, "abr": true, "mai": true, "jun": true, "jul": true, "ago": true,
+	"set": true, "out": true, "nov": true, "dez": true, "vs": true, "obs": true, "aprox": true,
+}
+
+// Split divide o texto em parágrafos ou sentenças, preservando as posições
+// no texto original. Trechos repetidos (comuns em HTML extraído) são ignorados.
+func Split(text string, level string) []Segment {
+	var segments []Segment
+	seen := make(map[string]bool)
+
+	add := func(paragraph, start, end int) {
+		// Ajustar limites para não incluir espaços
+		for start < end {
+			r, size := utf8.DecodeRuneInString(text[start:end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			start += size
+		}
+		for end > start {
+			r, size := utf8.DecodeLastRuneInString(text[start:end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			end -= size
+		}
+		if start >= end {
+			return
+		}
+		segmentText := text[start:end]
+		if seen[segmentText] {
+			return
+		}
+		seen[segmentText] = true
+		segments = append(segments, Segment{
+			Index:     len(segments),
+			Paragraph: paragraph,
+			Start:     start,
+			End:       end,
+			Text:      segmentText,
+		})
+	}
+
+	paragraph := 0
+	start := 0
+	bounds := append(paragraphRegex.FindAllStringIndex(text, -1), []int{len(text), len(text)})
+	for _, bound := range bounds {
+		if strings.TrimSpace(text[start:bound[0]]) == "" {
+			start = bound[1]
+			continue
+		}
+		if level == LevelSentence {
+			for _, sentence := range splitSentences(text[start:bound[0]]) {
+				add(paragraph, start+sentence[0], start+sentence[1])
+			}
+		} else {
+			add(paragraph, start, bound[0])
+		}
+		paragraph++
+		start = bound[1]
+	}
+
+	return segments
+}
+
+// splitSentences retorna os intervalos das sentenças de um parágrafo
+func splitSentences(text string) [][2]int {
+	var sentences [][2]int
+	start := 0
+
+	for _, loc := range sentenceEndRegex.FindAllStringIndex(text, -1) {
+		if !isSentenceEnd(text, loc[0], loc[1]) {
+			continue
+		}
+		sentences = append(sentences, [2]int{start, loc[1]})
+		start = loc[1]
+	}
+
+	if start < len(text) {
+		sentences = append(sentences, [2]int{start, len(text)})
+	}
+	return sentences
+}
+
+// isSentenceEnd verifica se a pontuação em text[punct:next] encerra uma sentença
+func isSentenceEnd(text string, punct, next int) bool {
+	// A próxima sentença deve começar com maiúscula, dígito ou aspas
+	if next < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[next:])
+		if !unicode.IsUpper(r) && !unicode.IsDigit(r) && !strings.ContainsRune("\"'“‘(—-", r) {
+			return false
+		}
+	}
+
+	// Ignorar abreviações e iniciais ("Dr.", "J. Silva")
+	if text[punct] == '.' {
+		wordStart := strings.LastIndexFunc(text[:punct], func(r rune) bool {
+			return unicode.IsSpace(r) || r == '('
+		}) + 1
+		word := strings.ToLower(text[wordStart:punct])
+		if abbreviations[word] || utf8.RuneCountInString(word) == 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package selection
+
+import "math"
+
+// score calcula o escore do termo pelo método; as medidas supervisionadas usam a
+// tabela de contingência entre a presença do termo no documento e a classe
+func score(method string, term Term, classDocs map[string]int, documents int) float64 {
+	switch method {
+	case MethodChi2:
+		return maxOverClasses(term, classDocs, documents, chiSquared)
+	case MethodMutualInfo:
+		return maxOverClasses(term, classDocs, documents, mutualInformation)
+	case MethodInfoGain:
+		return informationGain(term, classDocs, documents)
+	}
+	return float64(term.Count)
+}
+
+// maxOverClasses aplica a medida a cada classe contra as demais e retorna o maior valor.
+// n11: documentos da classe com o termo, n10: de outras classes com o termo,
+// n01: da classe sem o termo, n00: de outras classes sem o termo.
+func maxOverClasses(term Term, classDocs map[string]int, documents int, measure func(n11, n10, n01, n00 float64) float64) float64 {
+	best := 0.0
+	for class, count := range classDocs {
+		n11 := float64(term.ClassDF[class])
+		n10 := float64(term.DF) - n11
+		n01 := float64(count) - n11
+		n00 := float64(documents-count) - n10
+		best = math.Max(best, measure(n11, n10, n01, n00))
+	}
+	return best
+}
+
+// chiSquared é a estatística χ² da tabela 2×2
+func chiSquared(n11, n10, n01, n00 float64) float64 {
+	n := n11 + n10 + n01 + n00
+	denominator := (n11 + n01) * (n10 + n00) * (n11 + n10) * (n01 + n00)
+	if denominator == 0 {
+		return 0
+	}
+	d := n11*n00 - n10*n01
+	return n * d * d / denominator
+}
+
+// mutualInformation é a informação mútua (em bits) entre presença do termo e classe
+func mutualInformation(n11, n10, n01, n00 float64) float64 {
+	n := n11 + n10 + n01 + n00
+	cell := func(nij, row, column float64) float64 {
+		if nij == 0 {
+			return 0
+		}
+		return nij / n * math.Log2(n*nij/(row*column))
+	}
+	return cell(n11, n11+n10, n11+n01) + cell(n10, n11+n10, n10+n00) +
+		cell(n01, n01+n00, n11+n01) + cell(n00, n01+n00, n10+n00)
+}
+
+// informationGain é a redução da entropia das classes ao saber se o termo está presente:
+// H(C) − P(t)·H(C | t) − P(¬t)·H(C | ¬t)
+func informationGain(term Term, classDocs map[string]int, documents int) float64 {
+	n := float64(documents)
+	with, without := float64(term.DF), n-float64(term.DF)
+	var all, present, absent []float64
+	for class, count := range classDocs {
+		all = append(all, float64(count))
+		present = append(present, float64(term.ClassDF[class]))
+		absent = append(absent, float64(count-term.ClassDF[class]))
+	}
+	return entropy(all, n) - with/n*entropy(present, with) - without/n*entropy(absent, without)
+}
+
+// entropy é a entropia (em bits) da distribuição dada por contagens de soma total
+func entropy(counts []float64, total float64) float64 {
+	h := 0.0
+	for _, count := range counts {
+		if count > 0 {
+			p := count / total
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
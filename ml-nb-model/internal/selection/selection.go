@@ -0,0 +1,177 @@
+package selection
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Métodos de ranqueamento dos termos
+const (
+	MethodFrequency  = "frequency" // ocorrências no corpus
+	MethodChi2       = "chi2"      // qui-quadrado entre presença do termo e classe
+	MethodMutualInfo = "mi"        // informação mútua entre presença do termo e classe
+	MethodInfoGain   = "ig"        // ganho de informação sobre a distribuição de classes
+)
+
+// Methods lista os métodos de ranqueamento disponíveis
+var Methods = []string{MethodFrequency, MethodChi2, MethodMutualInfo, MethodInfoGain}
+
+// Options configura a seleção do vocabulário: os termos que passam pelos limites de
+// frequência de documentos são ordenados pelo método e os Size primeiros são mantidos
+type Options struct {
+	Method string
+	MinDF  int     // documentos mínimos com o termo
+	MaxDF  float64 // fração máxima dos documentos com o termo (1 = sem limite)
+	Size   int     // termos mantidos (0 = todos)
+}
+
+// DefaultOptions retorna a seleção padrão: todos os termos, por frequência
+func DefaultOptions() Options {
+	return Options{Method: MethodFrequency, MinDF: 1, MaxDF: 1}
+}
+
+// Validate verifica o método e os limites
+func (o Options) Validate() error {
+	known := false
+	for _, method := range Methods {
+		known = known || o.Method == method
+	}
+	if !known {
+		return fmt.Errorf("seleção desconhecida: %s (use %s)", o.Method, strings.Join(Methods, ", "))
+	}
+	if o.MinDF < 1 || o.MaxDF <= 0 || o.MaxDF > 1 || o.Size < 0 {
+		return fmt.Errorf("min_df deve ser ≥ 1, max_df deve estar em (0, 1] e o tamanho deve ser ≥ 0")
+	}
+	return nil
+}
+
+// Term é um termo candidato com o seu escore e as suas frequências
+type Term struct {
+	Term    string         `json:"term"`
+	Score   float64        `json:"score"`
+	Count   int            `json:"count"`    // ocorrências no corpus
+	DF      int            `json:"df"`       // documentos com o termo
+	ClassDF map[string]int `json:"class_df"` // documentos de cada classe com o termo
+}
+
+// Vocabulary é o vocabulário selecionado, em ordem decrescente de escore
+type Vocabulary struct {
+	Options
+	Documents  int
+	Candidates int // termos distintos antes da seleção
+	Classes    []string
+	Terms      []Term
+}
+
+// Select seleciona o vocabulário dos textos (tokenizados com PreprocessText) e rótulos
+func Select(texts, labels []string, opts Options) (*Vocabulary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	vocab := &Vocabulary{Options: opts, Documents: len(texts), Classes: models.SortedLabels(labels)}
+	classDocs := make(map[string]int)
+	terms := make(map[string]*Term)
+	for i, text := range texts {
+		classDocs[labels[i]]++
+		seen := make(map[string]bool)
+		for _, token := range utils.PreprocessText(text) {
+			term := terms[token]
+			if term == nil {
+				term = &Term{Term: token, ClassDF: make(map[string]int)}
+				terms[token] = term
+			}
+			term.Count++
+			if !seen[token] {
+				seen[token] = true
+				term.DF++
+				term.ClassDF[labels[i]]++
+			}
+		}
+	}
+	vocab.Candidates = len(terms)
+
+	maxDF := opts.MaxDF * float64(len(texts))
+	for _, term := range terms {
+		if term.DF < opts.MinDF || (opts.MaxDF < 1 && float64(term.DF) > maxDF) {
+			continue
+		}
+		term.Score = score(opts.Method, *term, classDocs, len(texts))
+		vocab.Terms = append(vocab.Terms, *term)
+	}
+
+	sort.Slice(vocab.Terms, func(i, j int) bool {
+		a, b := vocab.Terms[i], vocab.Terms[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Term < b.Term
+	})
+	if opts.Size > 0 && len(vocab.Terms) > opts.Size {
+		vocab.Terms = vocab.Terms[:opts.Size]
+	}
+	return vocab, nil
+}
+
+// Words retorna os termos selecionados, em ordem de escore
+func (v *Vocabulary) Words() []string {
+	words := make([]string, len(v.Terms))
+	for i, term := range v.Terms {
+		words[i] = term.Term
+	}
+	return words
+}
+
+// Pruned indica se algum termo dos textos ficou fora do vocabulário
+func (v *Vocabulary) Pruned() bool {
+	return len(v.Terms) < v.Candidates
+}
+
+// WriteCSV grava o vocabulário: posição, termo, escore, ocorrências, documentos
+// e documentos de cada classe com o termo
+func (v *Vocabulary) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"rank", "term", v.Method, "count", "df"}
+	for _, class := range v.Classes {
+		header = append(header, "df_"+class)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i, term := range v.Terms {
+		row := []string{strconv.Itoa(i + 1), term.Term, strconv.FormatFloat(term.Score, 'g', 6, 64),
+			strconv.Itoa(term.Count), strconv.Itoa(term.DF)}
+		for _, class := range v.Classes {
+			row = append(row, strconv.Itoa(term.ClassDF[class]))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Save grava o vocabulário em CSV (ver WriteCSV)
+func (v *Vocabulary) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := v.WriteCSV(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,38 @@
+package stats
+
+import "math"
+
+// LogOdds calcula, para cada classe contra as demais, o escore z da razão de log-odds
+// com prior de Dirichlet informativo (Monroe, Colaresi e Quinn, 2008): o prior de cada
+// termo é a sua contagem no corpus inteiro, o que encolhe as diferenças de termos raros.
+//
+//	δ_w = log[(y_cw + α_w) / (n_c + α_0 − y_cw − α_w)] − log[(y_ow + α_w) / (n_o + α_0 − y_ow − α_w)]
+//	z_w = δ_w / √(1/(y_cw + α_w) + 1/(y_ow + α_w))
+//
+// onde c é a classe, o as demais classes e n as contagens totais de termos.
+func LogOdds(counts map[string]map[string]int) map[string]map[string]float64 {
+	prior := make(map[string]float64)
+	alpha0 := 0.0
+	classTotals := make(map[string]float64)
+	for class, terms := range counts {
+		for term, count := range terms {
+			prior[term] += float64(count)
+			alpha0 += float64(count)
+			classTotals[class] += float64(count)
+		}
+	}
+
+	scores := make(map[string]map[string]float64)
+	for class, terms := range counts {
+		scores[class] = make(map[string]float64)
+		nc := classTotals[class]
+		no := alpha0 - nc
+		for term, alpha := range prior {
+			yc := float64(terms[term])
+			yo := alpha - yc // a contagem no corpus é também o prior do termo
+			delta := math.Log((yc+alpha)/(nc+alpha0-yc-alpha)) - math.Log((yo+alpha)/(no+alpha0-yo-alpha))
+			scores[class][term] = delta / math.Sqrt(1/(yc+alpha)+1/(yo+alpha))
+		}
+	}
+	return scores
+}
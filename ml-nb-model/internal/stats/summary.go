@@ -0,0 +1,260 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Summary resume um dataset: documentos e tamanhos por classe, vocabulário,
+// termos característicos de cada classe e campos vazios
+type Summary struct {
+	Documents  int            `json:"documents"`
+	Usable     int            `json:"usable"` // documentos com texto e rótulo
+	Tokens     int            `json:"tokens"`
+	Vocabulary int            `json:"vocabulary"`
+	Words      Distribution   `json:"words"`      // palavras por documento (todos os documentos utilizáveis)
+	Characters Distribution   `json:"characters"` // caracteres por documento
+	Histogram  []Bin          `json:"histogram"`  // palavras por documento, por classe
+	Classes    []ClassSummary `json:"classes"`
+	Missing    map[string]int `json:"missing_fields"`         // campos vazios dos documentos
+	Records    *RecordSummary `json:"news_records,omitempty"` // colunas vazias dos pares do FakeTrue.Br
+}
+
+// ClassSummary resume os documentos de uma classe
+type ClassSummary struct {
+	Label      string       `json:"label"`
+	Documents  int          `json:"documents"`
+	Share      float64      `json:"share"` // fração dos documentos utilizáveis
+	Tokens     int          `json:"tokens"`
+	Vocabulary int          `json:"vocabulary"`
+	Words      Distribution `json:"words"`
+	Characters Distribution `json:"characters"`
+	Frequent   []Term       `json:"frequent"` // termos mais frequentes
+	LogOdds    []Term       `json:"log_odds"` // termos mais característicos (z da razão de log-odds)
+}
+
+// Term é um termo com a sua contagem na classe e, em LogOdds, o escore z
+type Term struct {
+	Term  string  `json:"term"`
+	Count int     `json:"count"`
+	Score float64 `json:"score,omitempty"`
+}
+
+// Distribution resume uma distribuição de tamanhos
+type Distribution struct {
+	Min    int     `json:"min"`
+	P10    int     `json:"p10"`
+	P25    int     `json:"p25"`
+	Median int     `json:"median"`
+	P75    int     `json:"p75"`
+	P90    int     `json:"p90"`
+	Max    int     `json:"max"`
+	Mean   float64 `json:"mean"`
+	Std    float64 `json:"std"`
+}
+
+// Bin é uma faixa do histograma de palavras por documento, com a contagem de cada classe
+type Bin struct {
+	Lower  int            `json:"lower"`
+	Upper  int            `json:"upper"` // exclusivo, exceto na última faixa
+	Counts map[string]int `json:"counts"`
+}
+
+// RecordSummary conta os campos vazios de cada coluna dos pares do FakeTrue.Br
+type RecordSummary struct {
+	Records int            `json:"records"`
+	Empty   map[string]int `json:"empty"`
+}
+
+// Options configura o resumo
+type Options struct {
+	Top  int // termos por classe em Frequent e LogOdds
+	Bins int // faixas do histograma
+}
+
+// DefaultOptions retorna as opções padrão do resumo
+func DefaultOptions() Options {
+	return Options{Top: 15, Bins: 10}
+}
+
+// DocumentFields são os campos de models.Document contados em Summary.Missing
+var DocumentFields = []string{"id", "label", "text", "title", "url", "source", "date"}
+
+// RecordColumns são as colunas do FakeTrue.Br contadas em RecordSummary.Empty
+var RecordColumns = []string{"title_fake", "fake", "link_fake", "true", "link_true"}
+
+// Summarize resume os documentos (e, se não forem nil, os pares do FakeTrue.Br de onde vieram)
+func Summarize(docs []models.Document, records []models.NewsRecord, opts Options) Summary {
+	summary := Summary{Documents: len(docs), Missing: make(map[string]int)}
+	for _, doc := range docs {
+		fields := []string{doc.ID, doc.Label, doc.Text, doc.Title, doc.URL, doc.Source, doc.Date}
+		for i, field := range DocumentFields {
+			if strings.TrimSpace(fields[i]) == "" {
+				summary.Missing[field]++
+			}
+		}
+	}
+	if records != nil {
+		summary.Records = summarizeRecords(records)
+	}
+
+	var words, characters []int
+	byClass := make(map[string][]int)
+	counts := make(map[string]map[string]int) // [classe][termo]
+	total := make(map[string]int)
+	labels := models.Labels(docs)
+	for _, label := range labels {
+		counts[label] = make(map[string]int)
+	}
+	classSummaries := make(map[string]*ClassSummary)
+	classChars := make(map[string][]int)
+	for _, label := range labels {
+		classSummaries[label] = &ClassSummary{Label: label}
+	}
+
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.Text) == "" || doc.Label == "" {
+			continue
+		}
+		tokens := utils.PreprocessText(doc.Text)
+		length := len(strings.Fields(doc.Text))
+		chars := len([]rune(doc.Text))
+		words = append(words, length)
+		characters = append(characters, chars)
+		byClass[doc.Label] = append(byClass[doc.Label], length)
+		classChars[doc.Label] = append(classChars[doc.Label], chars)
+
+		class := classSummaries[doc.Label]
+		class.Documents++
+		class.Tokens += len(tokens)
+		for _, token := range tokens {
+			counts[doc.Label][token]++
+			total[token]++
+		}
+	}
+
+	summary.Usable = len(words)
+	summary.Vocabulary = len(total)
+	summary.Words = Describe(words)
+	summary.Characters = Describe(characters)
+	summary.Histogram = Histogram(byClass, opts.Bins)
+	scores := LogOdds(counts)
+	for _, label := range labels {
+		class := classSummaries[label]
+		summary.Tokens += class.Tokens
+		class.Share = float64(class.Documents) / math.Max(float64(summary.Usable), 1)
+		class.Vocabulary = len(counts[label])
+		class.Words = Describe(byClass[label])
+		class.Characters = Describe(classChars[label])
+		class.Frequent = topTerms(counts[label], nil, opts.Top)
+		class.LogOdds = topTerms(counts[label], scores[label], opts.Top)
+		summary.Classes = append(summary.Classes, *class)
+	}
+	return summary
+}
+
+// summarizeRecords conta as colunas vazias dos pares do FakeTrue.Br
+func summarizeRecords(records []models.NewsRecord) *RecordSummary {
+	summary := &RecordSummary{Records: len(records), Empty: make(map[string]int)}
+	for _, record := range records {
+		fields := []string{record.TitleFake, record.FakeText, record.LinkFake, record.TrueText, record.LinkTrue}
+		for i, column := range RecordColumns {
+			if strings.TrimSpace(fields[i]) == "" {
+				summary.Empty[column]++
+			}
+		}
+	}
+	return summary
+}
+
+// topTerms retorna os n termos de maior escore (ou, sem escores, de maior contagem);
+// com escores, só entram termos de escore positivo
+func topTerms(counts map[string]int, scores map[string]float64, n int) []Term {
+	var terms []Term
+	for term, count := range counts {
+		if scores == nil {
+			terms = append(terms, Term{Term: term, Count: count})
+		} else if scores[term] > 0 {
+			terms = append(terms, Term{Term: term, Count: count, Score: scores[term]})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Score != terms[j].Score {
+			return terms[i].Score > terms[j].Score
+		}
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+// Describe calcula o resumo de uma distribuição de tamanhos (quantis pelo posto mais próximo)
+func Describe(values []int) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	quantile := func(q float64) int {
+		return sorted[int(math.Round(q*float64(len(sorted)-1)))]
+	}
+
+	sum, sq := 0.0, 0.0
+	for _, v := range sorted {
+		sum += float64(v)
+		sq += float64(v) * float64(v)
+	}
+	mean := sum / float64(len(sorted))
+	return Distribution{
+		Min:    sorted[0],
+		P10:    quantile(0.1),
+		P25:    quantile(0.25),
+		Median: quantile(0.5),
+		P75:    quantile(0.75),
+		P90:    quantile(0.9),
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean,
+		Std:    math.Sqrt(math.Max(sq/float64(len(sorted))-mean*mean, 0)),
+	}
+}
+
+// Histogram divide o intervalo dos valores em faixas de mesma largura e conta os
+// valores de cada classe em cada faixa
+func Histogram(byClass map[string][]int, bins int) []Bin {
+	lower, upper := math.MaxInt, math.MinInt
+	for _, values := range byClass {
+		for _, v := range values {
+			if v < lower {
+				lower = v
+			}
+			if v > upper {
+				upper = v
+			}
+		}
+	}
+	if bins < 1 || lower > upper {
+		return nil
+	}
+	bins = utils.Min(bins, upper-lower+1)
+	width := (upper - lower + bins) / bins // arredondado para cima
+	bins = (upper-lower)/width + 1         // sem faixas vazias além do máximo
+	histogram := make([]Bin, bins)
+	for i := range histogram {
+		histogram[i] = Bin{Lower: lower + i*width, Upper: lower + (i+1)*width, Counts: make(map[string]int)}
+	}
+	for class, values := range byClass {
+		for _, v := range values {
+			histogram[utils.Min((v-lower)/width, bins-1)].Counts[class]++
+		}
+	}
+	return histogram
+}
@@ -0,0 +1,274 @@
+package stylometry
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/segment"
+)
+
+// Feature descreve uma característica de estilo
+type Feature struct {
+	Name        string
+	Description string
+}
+
+// Features são as características calculadas por Extract, na ordem do vetor
+var Features = []Feature{
+	{"exclamations", "exclamações por sentença"},
+	{"questions", "interrogações por sentença"},
+	{"ellipses", "reticências por sentença"},
+	{"quotes", "aspas por sentença"},
+	{"caps_words", "fração de palavras em maiúsculas"},
+	{"caps_chars", "fração de letras maiúsculas"},
+	{"sentence_length", "palavras por sentença"},
+	{"word_length", "letras por palavra"},
+	{"long_words", "fração de palavras com 10 letras ou mais"},
+	{"type_token", "palavras distintas / palavras"},
+	{"first_person", "fração de pronomes de 1ª pessoa"},
+	{"second_person", "fração de pronomes de 2ª pessoa"},
+	{"sensational", "fração de palavras sensacionalistas"},
+	{"digits", "fração de palavras com dígitos"},
+	{"length", "log(1 + palavras)"},
+}
+
+// MetadataFeatures são as características extraídas dos metadados por ExtractDocument,
+// depois das de Features
+var MetadataFeatures = []Feature{
+	{"title_exclamations", "exclamações no título"},
+	{"title_caps_words", "fração de palavras do título em maiúsculas"},
+	{"title_length", "palavras no título"},
+	{"has_url", "documento com link (0 ou 1)"},
+}
+
+// DocumentFeatures retorna as características de ExtractDocument comparáveis entre
+// as classes dos documentos: Features e MetadataFeatures, sem as do título quando
+// alguma classe não tem títulos. No FakeTrue.Br só as notícias falsas têm título,
+// e as características do título separariam as classes por um artefato do corpus.
+func DocumentFeatures(docs []models.Document) []Feature {
+	features := append([]Feature(nil), Features...)
+	dropTitle := len(UntitledClasses(docs)) > 0
+	for _, feature := range MetadataFeatures {
+		if dropTitle && strings.HasPrefix(feature.Name, "title_") {
+			continue
+		}
+		features = append(features, feature)
+	}
+	return features
+}
+
+// UntitledClasses retorna as classes (em ordem alfabética) sem nenhum documento com título
+func UntitledClasses(docs []models.Document) []string {
+	titled := make(map[string]bool)
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.Title) != "" {
+			titled[doc.Label] = true
+		}
+	}
+	var untitled []string
+	for _, label := range models.Labels(docs) {
+		if !titled[label] {
+			untitled = append(untitled, label)
+		}
+	}
+	return untitled
+}
+
+// project seleciona dos valores de ExtractDocument os das características informadas
+func project(values []float64, features []Feature) []float64 {
+	positions := make(map[string]int, len(values))
+	for i, feature := range append(append([]Feature(nil), Features...), MetadataFeatures...) {
+		positions[feature.Name] = i
+	}
+	projected := make([]float64, len(features))
+	for i, feature := range features {
+		projected[i] = values[positions[feature.Name]]
+	}
+	return projected
+}
+
+// Names retorna os nomes das características de Features
+func Names() []string {
+	names := make([]string, len(Features))
+	for i, feature := range Features {
+		names[i] = feature.Name
+	}
+	return names
+}
+
+// firstPerson são pronomes e possessivos de 1ª pessoa
+var firstPerson = wordSet("eu me mim comigo meu minha meus minhas nós nos conosco nosso nossa nossos nossas")
+
+// secondPerson são pronomes e possessivos de 2ª pessoa (incluindo "você")
+var secondPerson = wordSet("tu te ti contigo teu tua teus tuas você vocês vós vos vosso vossa vossos vossas")
+
+// sensational são palavras típicas de manchetes sensacionalistas e correntes
+var sensational = wordSet(`urgente urgentíssimo chocante bombástico bombástica bomba absurdo absurda
+	inacreditável incrível escândalo escandaloso escandalosa exclusivo exclusiva revelado revelada
+	segredo secreto secreta proibido proibida censurado censurada compartilhe compartilhem repassem
+	divulguem alerta atenção cuidado urgência vergonha vergonhoso vergonhosa mentira farsa golpe
+	milagre milagroso milagrosa cura escondem escondido escondida verdade bizarro bizarra`)
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// Extract calcula as características de estilo do texto bruto (antes de
+// PreprocessText, que remove pontuação e caixa), na ordem de Features
+func Extract(text string) []float64 {
+	sentences := float64(len(segment.Split(text, segment.LevelSentence)))
+	if sentences == 0 {
+		sentences = 1
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	n := float64(len(words))
+
+	var capsWords, letters, upper, longWords, first, second, sens, digits float64
+	distinct := make(map[string]bool)
+	for _, word := range words {
+		lower := strings.ToLower(word)
+		distinct[lower] = true
+
+		wordLetters, wordUpper, hasDigit := 0, 0, false
+		for _, r := range word {
+			switch {
+			case unicode.IsLetter(r):
+				wordLetters++
+				if unicode.IsUpper(r) {
+					wordUpper++
+				}
+			case unicode.IsDigit(r):
+				hasDigit = true
+			}
+		}
+		letters += float64(wordLetters)
+		upper += float64(wordUpper)
+		if wordLetters >= 2 && wordUpper == wordLetters {
+			capsWords++
+		}
+		if wordLetters >= 10 {
+			longWords++
+		}
+		if hasDigit {
+			digits++
+		}
+		if firstPerson[lower] {
+			first++
+		}
+		if secondPerson[lower] {
+			second++
+		}
+		if sensational[lower] {
+			sens++
+		}
+	}
+
+	ratio := func(a, b float64) float64 {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	}
+
+	return []float64{
+		float64(strings.Count(text, "!")) / sentences,
+		float64(strings.Count(text, "?")) / sentences,
+		float64(strings.Count(text, "...")+strings.Count(text, "…")) / sentences,
+		float64(strings.Count(text, `"`)+strings.Count(text, "“")+strings.Count(text, "”")) / sentences,
+		ratio(capsWords, n),
+		ratio(upper, letters),
+		n / sentences,
+		ratio(letters, n),
+		ratio(longWords, n),
+		ratio(float64(len(distinct)), n),
+		ratio(first, n),
+		ratio(second, n),
+		ratio(sens, n),
+		ratio(digits, n),
+		math.Log1p(n),
+	}
+}
+
+// ExtractDocument calcula as características do texto seguidas das dos
+// metadados do documento (título e link), na ordem de Features e MetadataFeatures
+func ExtractDocument(doc models.Document) []float64 {
+	values := Extract(doc.Text)
+
+	titleWords := strings.Fields(doc.Title)
+	capsWords := 0.0
+	for _, word := range titleWords {
+		letters, upper := 0, 0
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters >= 2 && upper == letters {
+			capsWords++
+		}
+	}
+	titleCaps := 0.0
+	if len(titleWords) > 0 {
+		titleCaps = capsWords / float64(len(titleWords))
+	}
+	hasURL := 0.0
+	if strings.TrimSpace(doc.URL) != "" {
+		hasURL = 1
+	}
+
+	return append(values,
+		float64(strings.Count(doc.Title, "!")),
+		titleCaps,
+		float64(len(titleWords)),
+		hasURL,
+	)
+}
+
+// ClassProfile é a média de cada característica nos documentos de uma classe
+type ClassProfile struct {
+	Label string
+	Count int
+	Mean  []float64 // na ordem de DocumentFeatures dos documentos do corpus
+}
+
+// Profiles calcula o perfil de estilo (ExtractDocument, restrito a DocumentFeatures)
+// de cada classe, em ordem alfabética
+func Profiles(docs []models.Document) []ClassProfile {
+	features := DocumentFeatures(docs)
+	byLabel := make(map[string][][]float64)
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.Text) == "" || doc.Label == "" {
+			continue
+		}
+		byLabel[doc.Label] = append(byLabel[doc.Label], project(ExtractDocument(doc), features))
+	}
+
+	var profiles []ClassProfile
+	for _, label := range models.Labels(docs) {
+		rows := byLabel[label]
+		dim := len(features)
+		profile := ClassProfile{Label: label, Count: len(rows), Mean: make([]float64, dim)}
+		for _, row := range rows {
+			for i, value := range row {
+				profile.Mean[i] += value
+			}
+		}
+		for i := range profile.Mean {
+			profile.Mean[i] /= math.Max(float64(len(rows)), 1)
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles
+}
@@ -0,0 +1,38 @@
+package stylometry
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// WriteCSV grava as características de estilo de cada documento (ExtractDocument):
+// id, rótulo, uma coluna por característica de DocumentFeatures e o título
+func WriteCSV(w io.Writer, docs []models.Document) error {
+	writer := csv.NewWriter(w)
+	features := DocumentFeatures(docs)
+	header := []string{"id", "label"}
+	for _, feature := range features {
+		header = append(header, feature.Name)
+	}
+	header = append(header, "title")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		row := []string{doc.ID, doc.Label}
+		for _, value := range project(ExtractDocument(doc), features) {
+			row = append(row, strconv.FormatFloat(value, 'f', 4, 64))
+		}
+		row = append(row, doc.Title)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
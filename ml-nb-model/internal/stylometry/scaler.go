@@ -0,0 +1,50 @@
+package stylometry
+
+import "math"
+
+// Scaler padroniza as características de estilo (média 0 e desvio 1 nos
+// textos de treinamento), para combiná-las com vetores de termos
+type Scaler struct {
+	Mean   []float64
+	Scale  []float64
+	Weight float64 // multiplicador dos valores padronizados (Fit usa 1/√características)
+}
+
+// Fit calcula a média e o desvio de cada característica nos textos
+func (s *Scaler) Fit(texts []string) {
+	dim := len(Features)
+	s.Mean = make([]float64, dim)
+	s.Scale = make([]float64, dim)
+	sq := make([]float64, dim)
+	for _, text := range texts {
+		for i, value := range Extract(text) {
+			s.Mean[i] += value
+			sq[i] += value * value
+		}
+	}
+
+	// Com peso 1/√n, o bloco de estilo tem norma comparável à de um vetor de termos normalizado
+	s.Weight = 1 / math.Sqrt(float64(dim))
+	n := math.Max(float64(len(texts)), 1)
+	for i := range s.Mean {
+		s.Mean[i] /= n
+		s.Scale[i] = math.Sqrt(math.Max(sq[i]/n-s.Mean[i]*s.Mean[i], 0))
+		if s.Scale[i] < 1e-6 { // constante no corpus (tolerando o erro de arredondamento da variância)
+			s.Scale[i] = 1
+		}
+	}
+}
+
+// Transform retorna as características padronizadas do texto, multiplicadas por Weight
+func (s *Scaler) Transform(text string) []float64 {
+	values := Extract(text)
+	for i := range values {
+		values[i] = (values[i] - s.Mean[i]) / s.Scale[i] * s.Weight
+	}
+	return values
+}
+
+// Token é o nome usado para a característica nas contribuições dos classificadores
+func Token(index int) string {
+	return "[estilo] " + Features[index].Name
+}
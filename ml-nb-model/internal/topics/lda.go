@@ -0,0 +1,246 @@
+package topics
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/utils"
+)
+
+// Options configura o treinamento do LDA
+type Options struct {
+	Topics     int     // número de tópicos
+	Alpha      float64 // prior de Dirichlet das misturas de tópicos dos documentos (0 = 1/Topics)
+	Beta       float64 // prior de Dirichlet das distribuições de palavras dos tópicos
+	Iterations int     // varreduras do amostrador de Gibbs no corpus
+	MinCount   int     // frequência mínima de uma palavra no corpus
+	MaxDocFreq float64 // fração máxima de documentos com a palavra (1 = sem limite)
+	Seed       int64
+}
+
+// DefaultOptions retorna a configuração padrão: 10 tópicos e 200 iterações
+func DefaultOptions() Options {
+	return Options{Topics: 10, Beta: 0.01, Iterations: 200, MinCount: 2, MaxDocFreq: 0.5, Seed: 1}
+}
+
+// Model é um modelo LDA treinado por amostragem de Gibbs colapsada
+type Model struct {
+	Options
+	Words []string
+	Phi   [][]float64 // [tópico][palavra] P(palavra | tópico)
+	Theta [][]float64 // [documento][tópico] mistura de tópicos dos documentos de treinamento
+	index map[string]int
+}
+
+// Train ajusta o LDA aos textos, tokenizados com PreprocessText; palavras raras
+// (MinCount) e muito comuns (MaxDocFreq) ficam fora do vocabulário
+func Train(texts []string, opts Options) (*Model, error) {
+	if opts.Topics < 2 || opts.Iterations < 1 {
+		return nil, fmt.Errorf("são necessários pelo menos 2 tópicos e 1 iteração")
+	}
+	if opts.Beta <= 0 || opts.Alpha < 0 {
+		return nil, fmt.Errorf("alpha e beta devem ser positivos")
+	}
+	if opts.Alpha == 0 {
+		opts.Alpha = 1 / float64(opts.Topics)
+	}
+
+	tokens := make([][]string, len(texts))
+	for i, text := range texts {
+		tokens[i] = utils.PreprocessText(text)
+	}
+	model := &Model{Options: opts}
+	model.buildVocabulary(tokens)
+	if len(model.Words) == 0 {
+		return nil, fmt.Errorf("nenhuma palavra no vocabulário (frequência mínima %d)", opts.MinCount)
+	}
+
+	docs := make([][]int, len(tokens))
+	for i, doc := range tokens {
+		docs[i] = model.ids(doc)
+	}
+	model.sample(docs, rand.New(rand.NewSource(opts.Seed)))
+	return model, nil
+}
+
+// buildVocabulary seleciona as palavras do modelo, em ordem alfabética
+func (m *Model) buildVocabulary(docs [][]string) {
+	counts := make(map[string]int)
+	docFreq := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool)
+		for _, token := range doc {
+			counts[token]++
+			if !seen[token] {
+				seen[token] = true
+				docFreq[token]++
+			}
+		}
+	}
+
+	maxDocs := m.MaxDocFreq * float64(len(docs))
+	for word, count := range counts {
+		if count >= m.MinCount && (m.MaxDocFreq >= 1 || float64(docFreq[word]) <= maxDocs) {
+			m.Words = append(m.Words, word)
+		}
+	}
+	sort.Strings(m.Words)
+	m.index = make(map[string]int, len(m.Words))
+	for i, word := range m.Words {
+		m.index[word] = i
+	}
+}
+
+// ids converte os tokens nos índices das palavras do vocabulário (ignorando as demais)
+func (m *Model) ids(tokens []string) []int {
+	ids := make([]int, 0, len(tokens))
+	for _, token := range tokens {
+		if id, ok := m.index[token]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// sample executa a amostragem de Gibbs colapsada: cada token recebe um novo tópico
+// com probabilidade ∝ (n_dk + α) · (n_kw + β) / (n_k + Vβ), sem o próprio token
+// nas contagens; Phi e Theta são estimados a partir das contagens finais
+func (m *Model) sample(docs [][]int, rng *rand.Rand) {
+	k, v := m.Topics, len(m.Words)
+	docTopic := make([][]int, len(docs))
+	topicWord := make([][]int, k)
+	for t := range topicWord {
+		topicWord[t] = make([]int, v)
+	}
+	topicTotal := make([]int, k)
+	assignments := make([][]int, len(docs))
+
+	for d, doc := range docs {
+		docTopic[d] = make([]int, k)
+		assignments[d] = make([]int, len(doc))
+		for i, word := range doc {
+			topic := rng.Intn(k)
+			assignments[d][i] = topic
+			docTopic[d][topic]++
+			topicWord[topic][word]++
+			topicTotal[topic]++
+		}
+	}
+
+	weights := make([]float64, k)
+	vBeta := float64(v) * m.Beta
+	for iteration := 0; iteration < m.Iterations; iteration++ {
+		for d, doc := range docs {
+			for i, word := range doc {
+				topic := assignments[d][i]
+				docTopic[d][topic]--
+				topicWord[topic][word]--
+				topicTotal[topic]--
+
+				for t := range weights {
+					weights[t] = (float64(docTopic[d][t]) + m.Alpha) *
+						(float64(topicWord[t][word]) + m.Beta) / (float64(topicTotal[t]) + vBeta)
+				}
+				topic = draw(weights, rng)
+
+				assignments[d][i] = topic
+				docTopic[d][topic]++
+				topicWord[topic][word]++
+				topicTotal[topic]++
+			}
+		}
+	}
+
+	m.Phi = make([][]float64, k)
+	for t := range m.Phi {
+		m.Phi[t] = make([]float64, v)
+		for w := range m.Phi[t] {
+			m.Phi[t][w] = (float64(topicWord[t][w]) + m.Beta) / (float64(topicTotal[t]) + vBeta)
+		}
+	}
+	m.Theta = make([][]float64, len(docs))
+	for d := range docs {
+		m.Theta[d] = mixture(docTopic[d], len(docs[d]), m.Alpha)
+	}
+}
+
+// mixture estima a mistura de tópicos de um documento pelas contagens de tópicos
+func mixture(counts []int, length int, alpha float64) []float64 {
+	theta := make([]float64, len(counts))
+	for t, count := range counts {
+		theta[t] = (float64(count) + alpha) / (float64(length) + float64(len(counts))*alpha)
+	}
+	return theta
+}
+
+// draw sorteia um índice com probabilidade proporcional aos pesos
+func draw(weights []float64, rng *rand.Rand) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	r := rng.Float64() * total
+	for i, w := range weights {
+		r -= w
+		if r < 0 {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
+
+// Infer estima a mistura de tópicos de um texto novo com os tópicos fixos (Phi),
+// por amostragem de Gibbs nas atribuições dos seus tokens; retorna também o número
+// de tokens do texto no vocabulário do modelo
+func (m *Model) Infer(text string) ([]float64, int) {
+	doc := m.ids(utils.PreprocessText(text))
+	rng := rand.New(rand.NewSource(m.Seed))
+	counts := make([]int, m.Topics)
+	assignments := make([]int, len(doc))
+	for i := range doc {
+		assignments[i] = rng.Intn(m.Topics)
+		counts[assignments[i]]++
+	}
+
+	weights := make([]float64, m.Topics)
+	iterations := m.Iterations / 2
+	if iterations < 20 {
+		iterations = 20
+	}
+	for iteration := 0; iteration < iterations; iteration++ {
+		for i, word := range doc {
+			counts[assignments[i]]--
+			for t := range weights {
+				weights[t] = (float64(counts[t]) + m.Alpha) * m.Phi[t][word]
+			}
+			assignments[i] = draw(weights, rng)
+			counts[assignments[i]]++
+		}
+	}
+	return mixture(counts, len(doc), m.Alpha), len(doc)
+}
+
+// WordWeight é uma palavra de um tópico e a sua probabilidade no tópico
+type WordWeight struct {
+	Word   string
+	Weight float64
+}
+
+// TopWords retorna as n palavras mais prováveis do tópico
+func (m *Model) TopWords(topic, n int) []WordWeight {
+	words := make([]WordWeight, len(m.Words))
+	for w, word := range m.Words {
+		words[w] = WordWeight{Word: word, Weight: m.Phi[topic][w]}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Weight != words[j].Weight {
+			return words[i].Weight > words[j].Weight
+		}
+		return words[i].Word < words[j].Word
+	})
+	if n < len(words) {
+		words = words[:n]
+	}
+	return words
+}
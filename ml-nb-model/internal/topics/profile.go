@@ -0,0 +1,73 @@
+package topics
+
+import (
+	"sort"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Profile relaciona os tópicos às classes dos documentos de treinamento
+type Profile struct {
+	Classes []string
+	Base    map[string]float64   // fração dos documentos em cada classe
+	Mixture map[string][]float64 // [classe][tópico] mistura média dos documentos da classe
+	Share   map[string][]float64 // [classe][tópico] P(classe | tópico): fração da massa do tópico na classe
+}
+
+// NewProfile calcula o perfil de tópicos das classes a partir das misturas (Theta)
+// e dos rótulos dos documentos de treinamento
+func NewProfile(theta [][]float64, labels []string) Profile {
+	profile := Profile{
+		Classes: models.SortedLabels(labels),
+		Base:    make(map[string]float64),
+		Mixture: make(map[string][]float64),
+		Share:   make(map[string][]float64),
+	}
+	if len(theta) == 0 {
+		return profile
+	}
+
+	topics := len(theta[0])
+	counts := make(map[string]int)
+	totals := make([]float64, topics)
+	for _, class := range profile.Classes {
+		profile.Mixture[class] = make([]float64, topics)
+		profile.Share[class] = make([]float64, topics)
+	}
+	for d, mixture := range theta {
+		class := labels[d]
+		counts[class]++
+		for t, p := range mixture {
+			profile.Mixture[class][t] += p
+			totals[t] += p
+		}
+	}
+
+	for _, class := range profile.Classes {
+		profile.Base[class] = float64(counts[class]) / float64(len(theta))
+		for t := range totals {
+			profile.Share[class][t] = profile.Mixture[class][t] / totals[t]
+			profile.Mixture[class][t] /= float64(counts[class])
+		}
+	}
+	return profile
+}
+
+// Lift é a sobrerrepresentação da classe no tópico: P(classe | tópico) / P(classe)
+// (acima de 1, o tópico é mais frequente na classe do que no corpus)
+func (p Profile) Lift(class string, topic int) float64 {
+	if p.Base[class] == 0 {
+		return 0
+	}
+	return p.Share[class][topic] / p.Base[class]
+}
+
+// Dominant retorna os tópicos em ordem decrescente de peso na mistura
+func Dominant(mixture []float64) []int {
+	order := make([]int, len(mixture))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return mixture[order[i]] > mixture[order[j]] })
+	return order
+}
@@ -0,0 +1,315 @@
+package tuning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/embeddings"
+	"github.com/souza/esw-008/ml-nb-model/internal/features"
+	"github.com/souza/esw-008/ml-nb-model/internal/linear"
+	"github.com/souza/esw-008/ml-nb-model/internal/mlp"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+	"github.com/souza/esw-008/ml-nb-model/internal/naivebayes"
+	"github.com/souza/esw-008/ml-nb-model/internal/neighbors"
+	"github.com/souza/esw-008/ml-nb-model/internal/retrieval"
+	"github.com/souza/esw-008/ml-nb-model/internal/selection"
+)
+
+// Algorithm descreve um algoritmo ajustável: seus hiperparâmetros padrão,
+// o espaço de busca declarado e como construir um classificador a partir deles
+type Algorithm struct {
+	Name     string
+	Defaults Params
+	Space    Space
+	Build    func(params Params) (models.Classifier, error)
+}
+
+// Algorithms são os algoritmos com hiperparâmetros ajustáveis
+var Algorithms = []Algorithm{
+	{
+		Name: "Naive Bayes",
+		Defaults: Params{
+			"alpha": "1", "selection": selection.MethodFrequency, "features": "0", "min_df": "1", "max_df": "1",
+			"hashing": "0", "ngrams": "1",
+		},
+		Space: Space{
+			{Name: "alpha", Values: []string{"0.05", "0.1", "0.25", "0.5", "1", "2"}, Min: 0.01, Max: 5, Log: true},
+			{Name: "selection", Values: []string{selection.MethodFrequency}},
+			{Name: "features", Values: []string{"0"}, Integer: true},
+			{Name: "min_df", Values: []string{"1"}, Integer: true},
+			{Name: "max_df", Values: []string{"1"}},
+			{Name: "hashing", Values: []string{"0"}, Integer: true},
+			{Name: "ngrams", Values: []string{"1"}, Integer: true},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			classifier := naivebayes.NewClassifier()
+			classifier.Alpha = params.Float("alpha", 1)
+			if classifier.Alpha <= 0 {
+				return nil, fmt.Errorf("alpha deve ser positivo")
+			}
+			var err error
+			classifier.Selection, err = SelectionOptions(params, "features")
+			if err != nil {
+				return nil, err
+			}
+			classifier.Hashing, err = HashingOptions(params, params.Int("hashing", 0))
+			if err != nil {
+				return nil, err
+			}
+			return classifier, nil
+		},
+	},
+	{
+		Name: "MLP",
+		Defaults: Params{
+			"vocab": "1000", "hidden": "50", "learning_rate": "0.01", "epochs": "100",
+			"input": mlp.InputBagOfWords, "embedding": embeddings.MethodSkipGram, "pooling": embeddings.WeightingTFIDF,
+			"dim": "50", "vectors": "", "stylometry": "false",
+			"selection": selection.MethodFrequency, "min_df": "1", "max_df": "1",
+			"hashing": "0", "ngrams": "1", "signed": "true",
+		},
+		Space: Space{
+			{Name: "vocab", Values: []string{"500", "1000", "2000"}, Min: 200, Max: 3000, Integer: true},
+			{Name: "hidden", Values: []string{"25", "50", "100"}, Min: 10, Max: 150, Integer: true},
+			{Name: "learning_rate", Values: []string{"0.005", "0.01", "0.05"}, Min: 0.001, Max: 0.1, Log: true},
+			{Name: "epochs", Values: []string{"10", "30"}, Min: 5, Max: 50, Integer: true},
+			{Name: "input", Values: []string{mlp.InputBagOfWords}},
+			{Name: "stylometry", Values: []string{"false"}},
+			{Name: "selection", Values: []string{selection.MethodFrequency}},
+			{Name: "min_df", Values: []string{"1"}, Integer: true},
+			{Name: "max_df", Values: []string{"1"}},
+			{Name: "hashing", Values: []string{"0"}, Integer: true},
+			{Name: "ngrams", Values: []string{"1"}, Integer: true},
+			{Name: "signed", Values: []string{"true"}},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			vocab, hidden := params.Int("vocab", 1000), params.Int("hidden", 50)
+			if vocab <= 0 || hidden <= 0 {
+				return nil, fmt.Errorf("vocab e hidden devem ser positivos")
+			}
+			classifier := mlp.NewClassifier(vocab, hidden, 2)
+			classifier.LearningRate = params.Float("learning_rate", classifier.LearningRate)
+			classifier.Epochs = params.Int("epochs", classifier.Epochs)
+
+			// Entrada por embeddings de documento ou por hashing (hashing > 0 substitui bow)
+			classifier.Input = params.String("input", mlp.InputBagOfWords)
+			if classifier.Input != mlp.InputBagOfWords && classifier.Input != mlp.InputEmbedding && classifier.Input != mlp.InputHashing {
+				return nil, fmt.Errorf("entrada desconhecida: %s (use bow, embedding ou hashing)", classifier.Input)
+			}
+			dim := params.Int("hashing", 0)
+			if dim > 0 && classifier.Input == mlp.InputEmbedding {
+				return nil, fmt.Errorf("hashing não se combina com a entrada embedding")
+			}
+			if dim > 0 {
+				classifier.Input = mlp.InputHashing
+			}
+			if classifier.Input == mlp.InputHashing {
+				if dim == 0 {
+					dim = vocab
+				}
+				hashing, err := HashingOptions(params, dim)
+				if err != nil {
+					return nil, err
+				}
+				signed, err := boolParam(params, "signed")
+				if err != nil {
+					return nil, err
+				}
+				hashing.Signed = signed
+				classifier.Hashing = hashing
+			}
+			classifier.Embedding.Method = params.String("embedding", embeddings.MethodSkipGram)
+			if classifier.Embedding.Method != embeddings.MethodSkipGram && classifier.Embedding.Method != embeddings.MethodGloVe {
+				return nil, fmt.Errorf("embedding desconhecido: %s (use sgns ou glove)", classifier.Embedding.Method)
+			}
+			classifier.Embedding.Dim = params.Int("dim", 50)
+			if classifier.Embedding.Dim <= 0 {
+				return nil, fmt.Errorf("dim deve ser positivo")
+			}
+			classifier.Weighting = params.String("pooling", embeddings.WeightingTFIDF)
+			if _, err := embeddings.NewEmbedder(nil, classifier.Weighting); err != nil {
+				return nil, err
+			}
+			if path := params.String("vectors", ""); path != "" {
+				vectors, err := embeddings.LoadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				classifier.Pretrained = vectors
+			}
+			stylometry, err := boolParam(params, "stylometry")
+			if err != nil {
+				return nil, err
+			}
+			classifier.Stylometry = stylometry
+			classifier.Selection, err = SelectionOptions(params, "vocab")
+			if err != nil {
+				return nil, err
+			}
+			return classifier, nil
+		},
+	},
+	{
+		Name:     "Regressão Logística",
+		Defaults: Params{"penalty": linear.PenaltyL2, "lambda": "0", "epochs": "10", "eta0": "0.5", "stylometry": "false"},
+		Space: Space{
+			{Name: "penalty", Values: []string{linear.PenaltyL1, linear.PenaltyL2}},
+			{Name: "lambda", Values: []string{"1e-05", "0.0001", "0.001"}, Min: 1e-6, Max: 1e-2, Log: true},
+			{Name: "epochs", Values: []string{"5", "10", "20"}, Min: 5, Max: 30, Integer: true},
+			{Name: "stylometry", Values: []string{"false"}},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			classifier, err := linear.NewLogisticRegression(params.String("penalty", linear.PenaltyL2), params.Float("lambda", 0))
+			if err != nil {
+				return nil, err
+			}
+			classifier.Epochs = params.Int("epochs", classifier.Epochs)
+			classifier.Eta0 = params.Float("eta0", classifier.Eta0)
+			classifier.Stylometry, err = boolParam(params, "stylometry")
+			if err != nil {
+				return nil, err
+			}
+			return classifier, nil
+		},
+	},
+	{
+		Name:     "SVM Linear",
+		Defaults: Params{"lambda": "0", "epochs": "10", "stylometry": "false"},
+		Space: Space{
+			{Name: "lambda", Values: []string{"0.0001", "0.001", "0.01", "0.1"}, Min: 1e-5, Max: 1, Log: true},
+			{Name: "epochs", Values: []string{"5", "10", "20"}, Min: 5, Max: 30, Integer: true},
+			{Name: "stylometry", Values: []string{"false"}},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			classifier := linear.NewSVM(params.Float("lambda", 0))
+			classifier.Epochs = params.Int("epochs", classifier.Epochs)
+			stylometry, err := boolParam(params, "stylometry")
+			if err != nil {
+				return nil, err
+			}
+			classifier.Stylometry = stylometry
+			return classifier, nil
+		},
+	},
+	{
+		Name:     "kNN",
+		Defaults: Params{"k": "5", "weighting": neighbors.WeightingCosine},
+		Space: Space{
+			{Name: "k", Values: []string{"1", "3", "5", "10", "20"}, Min: 1, Max: 30, Integer: true},
+			{Name: "weighting", Values: []string{neighbors.WeightingUniform, neighbors.WeightingCosine}},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			return NeighborsWithIndex("kNN", params, nil)
+		},
+	},
+	{
+		Name:     "Centroide",
+		Defaults: Params{"scale": "10"},
+		Space: Space{
+			{Name: "scale", Values: []string{"1", "5", "10", "20", "50"}, Min: 1, Max: 100, Log: true},
+		},
+		Build: func(params Params) (models.Classifier, error) {
+			return NeighborsWithIndex("Centroide", params, nil)
+		},
+	},
+}
+
+// NeighborsWithIndex cria o kNN ou o centroide com os parâmetros informados e o
+// índice TF-IDF dos documentos de treinamento (neighbors.NewIndex), para que os
+// dois compartilhem um único índice; com index nil, cada um indexa os próprios documentos
+func NeighborsWithIndex(algorithm string, params Params, index *retrieval.Index) (models.Classifier, error) {
+	switch algorithm {
+	case "kNN":
+		return neighbors.NewKNN(params.Int("k", 5), params.String("weighting", neighbors.WeightingCosine), index)
+	case "Centroide":
+		return neighbors.NewCentroid(params.Float("scale", 10), index)
+	}
+	return nil, fmt.Errorf("%s não é um classificador de vizinhos", algorithm)
+}
+
+// boolParam lê um parâmetro booleano, rejeitando valores diferentes de true/false
+func boolParam(params Params, name string) (bool, error) {
+	value := params.String(name, "false")
+	if value != "true" && value != "false" {
+		return false, fmt.Errorf("valor inválido para %s: %q (use true ou false)", name, value)
+	}
+	return params.Bool(name, false), nil
+}
+
+// SelectionOptions lê a seleção do vocabulário dos parâmetros selection, min_df, max_df
+// e do tamanho do vocabulário (parâmetro sizeParam; 0 = todos os termos)
+func SelectionOptions(params Params, sizeParam string) (selection.Options, error) {
+	opts := selection.Options{
+		Method: params.String("selection", selection.MethodFrequency),
+		MinDF:  params.Int("min_df", 1),
+		MaxDF:  params.Float("max_df", 1),
+		Size:   params.Int(sizeParam, 0),
+	}
+	return opts, opts.Validate()
+}
+
+// HashingOptions cria o vetorizador por hashing com dimensão dim e n-gramas até o
+// parâmetro ngrams (nil com dim 0: usar o vocabulário)
+func HashingOptions(params Params, dim int) (*features.HashingVectorizer, error) {
+	if dim < 0 {
+		return nil, fmt.Errorf("hashing deve ser positivo (0 = vocabulário)")
+	}
+	ngrams := params.Int("ngrams", 1)
+	if ngrams < 1 {
+		return nil, fmt.Errorf("ngrams deve ser pelo menos 1")
+	}
+	if dim == 0 {
+		return nil, nil
+	}
+	hashing := features.NewHashingVectorizer(dim)
+	hashing.NGrams = ngrams
+	return hashing, nil
+}
+
+// Lookup retorna o algoritmo ajustável com o nome informado
+func Lookup(name string) (Algorithm, bool) {
+	for _, algorithm := range Algorithms {
+		if algorithm.Name == name {
+			return algorithm, true
+		}
+	}
+	return Algorithm{}, false
+}
+
+// Validate verifica se os parâmetros são conhecidos e constroem um classificador válido
+func (a Algorithm) Validate(params Params) error {
+	for _, name := range params.Names() {
+		def, known := a.Defaults[name]
+		if !known {
+			return fmt.Errorf("%s: parâmetro desconhecido %s (disponíveis: %s)", a.Name, name, strings.Join(a.Defaults.Names(), ", "))
+		}
+		// Parâmetros numéricos precisam de valores numéricos
+		if _, err := strconv.ParseFloat(def, 64); err == nil {
+			if _, err := strconv.ParseFloat(params[name], 64); err != nil {
+				return fmt.Errorf("%s: valor inválido para %s: %q", a.Name, name, params[name])
+			}
+		}
+	}
+	if _, err := a.Build(a.Defaults.Merge(params)); err != nil {
+		return fmt.Errorf("%s: %v", a.Name, err)
+	}
+	return nil
+}
+
+// Factory retorna a fábrica do algoritmo com os parâmetros informados sobre os padrões
+func (a Algorithm) Factory(params Params) (models.Factory, error) {
+	if err := a.Validate(params); err != nil {
+		return nil, err
+	}
+	merged := a.Defaults.Merge(params)
+	return func() models.Classifier {
+		// Os parâmetros já foram validados, mas Build ainda pode falhar (ex.: arquivo de
+		// vetores removido depois da validação); um classificador nil falharia em Train
+		classifier, err := a.Build(merged)
+		if err != nil {
+			panic(fmt.Sprintf("%s: %v", a.Name, err))
+		}
+		return classifier
+	}, nil
+}
@@ -0,0 +1,81 @@
+package tuning
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config é o arquivo de configuração reutilizável com os hiperparâmetros de cada algoritmo
+type Config struct {
+	Algorithms map[string]Entry `json:"algorithms"`
+}
+
+// Entry são os hiperparâmetros de um algoritmo e, quando gerados pelo tune, a origem deles
+type Entry struct {
+	Params      Params  `json:"params"`
+	Search      string  `json:"search,omitempty"`
+	Metric      string  `json:"metric,omitempty"`
+	Score       float64 `json:"score,omitempty"`        // métrica média na cross-validation interna
+	NestedScore float64 `json:"nested_score,omitempty"` // estimativa da cross-validation aninhada
+	TunedAt     string  `json:"tuned_at,omitempty"`     // RFC 3339
+}
+
+// NewConfig cria uma configuração vazia
+func NewConfig() *Config {
+	return &Config{Algorithms: make(map[string]Entry)}
+}
+
+// LoadConfig lê e valida um arquivo de configuração
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := NewConfig()
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("configuração inválida em %s: %v", path, err)
+	}
+	if config.Algorithms == nil {
+		config.Algorithms = make(map[string]Entry)
+	}
+
+	for name, entry := range config.Algorithms {
+		algorithm, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("algoritmo desconhecido em %s: %s", path, name)
+		}
+		if err := algorithm.Validate(entry.Params); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// Params retorna os hiperparâmetros configurados do algoritmo (nil se ausente)
+func (c *Config) Params(algorithm string) Params {
+	return c.Algorithms[algorithm].Params
+}
+
+// Record registra a melhor configuração de uma busca
+func (c *Config) Record(result *Result, search string) {
+	c.Algorithms[result.Algorithm] = Entry{
+		Params:      result.Best.Params,
+		Search:      search,
+		Metric:      result.Metric,
+		Score:       result.Best.Mean,
+		NestedScore: result.NestedMean,
+		TunedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// Save grava a configuração em JSON
+func (c *Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
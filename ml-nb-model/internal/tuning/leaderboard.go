@@ -0,0 +1,47 @@
+package tuning
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteLeaderboard grava o leaderboard em CSV: posição, um campo por
+// hiperparâmetro, média e desvio padrão da métrica, métricas por fold e tempo
+func WriteLeaderboard(w io.Writer, result *Result) error {
+	var names []string
+	if len(result.Leaderboard) > 0 {
+		names = result.Leaderboard[0].Params.Names()
+	}
+
+	writer := csv.NewWriter(w)
+	header := append([]string{"rank"}, names...)
+	header = append(header, result.Metric+"_mean", result.Metric+"_std", "fold_scores", "seconds")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i, trial := range result.Leaderboard {
+		row := []string{strconv.Itoa(i + 1)}
+		for _, name := range names {
+			row = append(row, trial.Params[name])
+		}
+		scores := make([]string, len(trial.Scores))
+		for j, score := range trial.Scores {
+			scores[j] = strconv.FormatFloat(score, 'f', 4, 64)
+		}
+		row = append(row,
+			strconv.FormatFloat(trial.Mean, 'f', 4, 64),
+			strconv.FormatFloat(trial.Std, 'f', 4, 64),
+			strings.Join(scores, ";"),
+			strconv.FormatFloat(trial.Duration.Seconds(), 'f', 2, 64),
+		)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,87 @@
+package tuning
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Params são os valores dos hiperparâmetros de uma configuração, por nome.
+// Os valores são guardados como texto para acomodar parâmetros numéricos e
+// categóricos (ex.: "penalty": "l1") no mesmo arquivo de configuração.
+type Params map[string]string
+
+// Float retorna o parâmetro como número (def quando ausente ou inválido)
+func (p Params) Float(name string, def float64) float64 {
+	value, err := strconv.ParseFloat(p[name], 64)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// Int retorna o parâmetro como inteiro (def quando ausente ou inválido)
+func (p Params) Int(name string, def int) int {
+	value, err := strconv.ParseFloat(p[name], 64)
+	if err != nil {
+		return def
+	}
+	return int(value + 0.5)
+}
+
+// Bool retorna o parâmetro como booleano ("true"/"false"; def quando ausente ou inválido)
+func (p Params) Bool(name string, def bool) bool {
+	value, err := strconv.ParseBool(p[name])
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// String retorna o parâmetro como texto (def quando ausente)
+func (p Params) String(name string, def string) string {
+	if value, ok := p[name]; ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// Names retorna os nomes dos parâmetros em ordem alfabética
+func (p Params) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge retorna uma cópia de p com os valores de other sobrepostos
+func (p Params) Merge(other Params) Params {
+	merged := make(Params, len(p)+len(other))
+	for name, value := range p {
+		merged[name] = value
+	}
+	for name, value := range other {
+		merged[name] = value
+	}
+	return merged
+}
+
+// Key formata os parâmetros como "nome=valor" em ordem alfabética
+func (p Params) Key() string {
+	parts := make([]string, 0, len(p))
+	for _, name := range p.Names() {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, p[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatValue formata um valor sorteado com poucos algarismos significativos
+func formatValue(value float64, integer bool) string {
+	if integer {
+		return strconv.Itoa(int(value + 0.5))
+	}
+	return strconv.FormatFloat(value, 'g', 3, 64)
+}
@@ -0,0 +1,334 @@
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/souza/esw-008/ml-nb-model/internal/evaluation"
+	"github.com/souza/esw-008/ml-nb-model/internal/models"
+)
+
+// Estratégias de busca
+const (
+	SearchGrid   = "grid"   // todas as combinações dos valores declarados
+	SearchRandom = "random" // configurações sorteadas do espaço
+)
+
+// Metrics são as métricas que podem guiar a busca
+var Metrics = []string{"accuracy", "precision", "recall", "f1", "ece"}
+
+// Options configura a busca de hiperparâmetros
+type Options struct {
+	Search     string // SearchGrid ou SearchRandom
+	Trials     int    // configurações sorteadas na busca aleatória
+	OuterFolds int    // folds externos (estimativa do desempenho da busca)
+	InnerFolds int    // folds internos (seleção da configuração)
+	Workers    int    // treinamentos em paralelo (0 = número de CPUs)
+	Metric     string // métrica otimizada (ver Metrics)
+	Seed       int64  // semente da busca aleatória
+
+	// Base são parâmetros fixos (ex.: de --config ou das flags globais) somados a cada
+	// configuração; os parâmetros da configuração prevalecem
+	Base Params
+
+	// Wrap (opcional) envolve a fábrica de cada configuração (ex.: aumento de dados)
+	Wrap func(models.Factory) models.Factory
+
+	// Progress (opcional) é chamado a cada treinamento concluído
+	Progress func(done, total int)
+}
+
+// DefaultOptions retorna as opções padrão da busca
+func DefaultOptions() Options {
+	return Options{
+		Search:     SearchGrid,
+		Trials:     20,
+		OuterFolds: 3,
+		InnerFolds: 3,
+		Metric:     "f1",
+		Seed:       1,
+	}
+}
+
+// Trial é uma configuração avaliada por cross-validation
+type Trial struct {
+	Params   Params
+	Scores   []float64 // métrica em cada fold
+	Mean     float64
+	Std      float64
+	Duration time.Duration // tempo total de treinamento e predição
+}
+
+// OuterFold é o resultado de um fold externo da cross-validation aninhada:
+// a configuração escolhida pela busca interna e seu desempenho no fold externo
+type OuterFold struct {
+	Params     Params
+	InnerScore float64
+	Score      float64
+}
+
+// Result é o resultado da busca de um algoritmo
+type Result struct {
+	Algorithm   string
+	Metric      string
+	Leaderboard []Trial     // configurações avaliadas em todos os dados, da melhor para a pior
+	Best        Trial       // configuração recomendada (primeira do leaderboard)
+	Outer       []OuterFold // cross-validation aninhada
+	NestedMean  float64     // estimativa não enviesada do desempenho da busca
+	NestedStd   float64
+}
+
+// task é um treinamento: uma configuração avaliada em um fold
+type task struct {
+	group     int // 0..OuterFolds-1 = folds externos; OuterFolds = todos os dados
+	candidate int
+	index     int // posição do fold no grupo
+	fold      models.Fold
+}
+
+// Tune busca os melhores hiperparâmetros do algoritmo com cross-validation aninhada.
+// Para cada fold externo, a busca com cross-validation interna nos dados de treinamento
+// escolhe uma configuração, avaliada em seguida no fold de teste externo; a média
+// desses resultados estima o desempenho do procedimento sem o viés da seleção.
+// A configuração recomendada é escolhida pela mesma busca sobre todos os dados.
+func Tune(docs []models.Document, algorithm Algorithm, space Space, opts Options) (*Result, error) {
+	if !validMetric(opts.Metric) {
+		return nil, fmt.Errorf("métrica desconhecida: %s (use %v)", opts.Metric, Metrics)
+	}
+	if opts.OuterFolds < 2 || opts.InnerFolds < 2 {
+		return nil, fmt.Errorf("são necessários pelo menos 2 folds externos e 2 internos")
+	}
+	if len(docs) < opts.OuterFolds*opts.InnerFolds {
+		return nil, fmt.Errorf("documentos insuficientes (%d) para %d×%d folds", len(docs), opts.OuterFolds, opts.InnerFolds)
+	}
+
+	candidates, err := Candidates(space, opts)
+	if err != nil {
+		return nil, err
+	}
+	factories := make([]models.Factory, len(candidates))
+	for i, params := range candidates {
+		candidates[i] = opts.Base.Merge(params)
+		if factories[i], err = algorithm.Factory(candidates[i]); err != nil {
+			return nil, err
+		}
+		if opts.Wrap != nil {
+			factories[i] = opts.Wrap(factories[i])
+		}
+	}
+
+	// Busca interna em cada fold externo e busca final sobre todos os dados
+	outerFolds := evaluation.CreateFolds(docs, opts.OuterFolds)
+	var tasks []task
+	for group := 0; group <= opts.OuterFolds; group++ {
+		data := docs
+		if group < opts.OuterFolds {
+			data = outerFolds[group].Train
+		}
+		for index, fold := range evaluation.CreateFolds(data, opts.InnerFolds) {
+			for candidate := range candidates {
+				tasks = append(tasks, task{group: group, candidate: candidate, index: index, fold: fold})
+			}
+		}
+	}
+
+	total := len(tasks) + opts.OuterFolds
+	tracker := &progress{total: total, report: opts.Progress}
+
+	trials := make([][]Trial, opts.OuterFolds+1)
+	for group := range trials {
+		trials[group] = make([]Trial, len(candidates))
+		for candidate, params := range candidates {
+			trials[group][candidate] = Trial{Params: params, Scores: make([]float64, opts.InnerFolds)}
+		}
+	}
+	var mu sync.Mutex
+	run(tasks, opts.Workers, func(t task) {
+		score, elapsed := scoreFold(factories[t.candidate], t.fold, opts.Metric)
+		mu.Lock()
+		trial := &trials[t.group][t.candidate]
+		trial.Scores[t.index] = score
+		trial.Duration += elapsed
+		mu.Unlock()
+		tracker.step()
+	})
+
+	for group := range trials {
+		for i := range trials[group] {
+			summarize(&trials[group][i])
+		}
+		rank(trials[group], opts.Metric)
+	}
+
+	// Reavaliar a melhor configuração de cada fold externo no seu fold de teste
+	result := &Result{
+		Algorithm:   algorithm.Name,
+		Metric:      opts.Metric,
+		Leaderboard: trials[opts.OuterFolds],
+		Best:        trials[opts.OuterFolds][0],
+		Outer:       make([]OuterFold, opts.OuterFolds),
+	}
+	var outerTasks []task
+	for group := 0; group < opts.OuterFolds; group++ {
+		best := trials[group][0]
+		result.Outer[group] = OuterFold{Params: best.Params, InnerScore: best.Mean}
+		for candidate, params := range candidates {
+			if params.Key() == best.Params.Key() {
+				outerTasks = append(outerTasks, task{group: group, candidate: candidate, fold: outerFolds[group]})
+			}
+		}
+	}
+	run(outerTasks, opts.Workers, func(t task) {
+		result.Outer[t.group].Score, _ = scoreFold(factories[t.candidate], t.fold, opts.Metric)
+		tracker.step()
+	})
+
+	outer := Trial{}
+	for _, fold := range result.Outer {
+		outer.Scores = append(outer.Scores, fold.Score)
+	}
+	summarize(&outer)
+	result.NestedMean, result.NestedStd = outer.Mean, outer.Std
+
+	return result, nil
+}
+
+// Candidates retorna as configurações a avaliar: a grade completa ou uma amostra aleatória
+func Candidates(space Space, opts Options) ([]Params, error) {
+	switch opts.Search {
+	case SearchGrid:
+		return space.Grid()
+	case SearchRandom:
+		if opts.Trials < 1 {
+			return nil, fmt.Errorf("a busca aleatória precisa de pelo menos 1 configuração")
+		}
+		return space.Sample(rand.New(rand.NewSource(opts.Seed)), opts.Trials), nil
+	}
+	return nil, fmt.Errorf("busca desconhecida: %s (use %s ou %s)", opts.Search, SearchGrid, SearchRandom)
+}
+
+// run executa as tarefas com um pool limitado de workers
+func run(tasks []task, workers int, fn func(task)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	queue := make(chan task)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				fn(t)
+			}
+		}()
+	}
+	for _, t := range tasks {
+		queue <- t
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// progress conta os treinamentos concluídos entre os workers
+type progress struct {
+	mu     sync.Mutex
+	done   int
+	total  int
+	report func(done, total int)
+}
+
+// step registra um treinamento concluído
+func (p *progress) step() {
+	if p.report == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.report(p.done, p.total)
+}
+
+// scoreFold treina um classificador no fold e retorna a métrica no conjunto de teste
+func scoreFold(factory models.Factory, fold models.Fold, metric string) (float64, time.Duration) {
+	start := time.Now()
+	classifier := factory()
+	classifier.Train(fold.Train)
+
+	texts, labels := models.Samples(fold.Test)
+	predictions := make([]evaluation.Prediction, len(texts))
+	for i, text := range texts {
+		predictions[i] = evaluation.Prediction{Text: text, Actual: labels[i], Result: classifier.Predict(text)}
+	}
+
+	return MetricValue(evaluation.Evaluate(predictions, 10), metric), time.Since(start)
+}
+
+// MetricValue retorna o valor da métrica informada
+func MetricValue(metrics models.Metrics, metric string) float64 {
+	switch metric {
+	case "accuracy":
+		return metrics.Accuracy
+	case "precision":
+		return metrics.Precision
+	case "recall":
+		return metrics.Recall
+	case "ece":
+		return metrics.ECE
+	}
+	return metrics.F1Score
+}
+
+// validMetric indica se a métrica é suportada
+func validMetric(metric string) bool {
+	for _, m := range Metrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerIsBetter indica se valores menores da métrica são melhores
+func lowerIsBetter(metric string) bool {
+	return metric == "ece"
+}
+
+// summarize calcula a média e o desvio padrão (amostral) das métricas por fold
+func summarize(trial *Trial) {
+	n := float64(len(trial.Scores))
+	if n == 0 {
+		return
+	}
+	sum := 0.0
+	for _, score := range trial.Scores {
+		sum += score
+	}
+	trial.Mean = sum / n
+
+	if n > 1 {
+		variance := 0.0
+		for _, score := range trial.Scores {
+			variance += (score - trial.Mean) * (score - trial.Mean)
+		}
+		trial.Std = math.Sqrt(variance / (n - 1))
+	}
+}
+
+// rank ordena as configurações da melhor para a pior (empates: menor desvio padrão)
+func rank(trials []Trial, metric string) {
+	sort.SliceStable(trials, func(i, j int) bool {
+		if trials[i].Mean != trials[j].Mean {
+			if lowerIsBetter(metric) {
+				return trials[i].Mean < trials[j].Mean
+			}
+			return trials[i].Mean > trials[j].Mean
+		}
+		return trials[i].Std < trials[j].Std
+	})
+}
@@ -0,0 +1,123 @@
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Param declara um hiperparâmetro e os valores que a busca pode experimentar
+type Param struct {
+	Name     string
+	Values   []string // valores da busca em grade (sorteados na busca aleatória quando não há intervalo)
+	Min, Max float64  // intervalo contínuo da busca aleatória (usado quando Max > Min)
+	Log      bool     // sorteia o intervalo em escala logarítmica
+	Integer  bool     // arredonda os valores sorteados
+}
+
+// Space é o espaço de busca de um algoritmo
+type Space []Param
+
+// Grid retorna todas as combinações dos valores declarados (produto cartesiano)
+func (s Space) Grid() ([]Params, error) {
+	grid := []Params{{}}
+	for _, param := range s {
+		if len(param.Values) == 0 {
+			return nil, fmt.Errorf("parâmetro %s não tem valores para a busca em grade", param.Name)
+		}
+		var next []Params
+		for _, params := range grid {
+			for _, value := range param.Values {
+				next = append(next, params.Merge(Params{param.Name: value}))
+			}
+		}
+		grid = next
+	}
+	return grid, nil
+}
+
+// Sample sorteia até n configurações distintas do espaço
+func (s Space) Sample(rng *rand.Rand, n int) []Params {
+	seen := make(map[string]bool)
+	var samples []Params
+	for attempt := 0; attempt < n*20 && len(samples) < n; attempt++ {
+		params := make(Params, len(s))
+		for _, param := range s {
+			params[param.Name] = param.sample(rng)
+		}
+		if key := params.Key(); !seen[key] {
+			seen[key] = true
+			samples = append(samples, params)
+		}
+	}
+	return samples
+}
+
+// sample sorteia um valor do parâmetro
+func (p Param) sample(rng *rand.Rand) string {
+	if p.Max <= p.Min {
+		return p.Values[rng.Intn(len(p.Values))]
+	}
+	if p.Log {
+		low, high := math.Log(p.Min), math.Log(p.Max)
+		return formatValue(math.Exp(low+rng.Float64()*(high-low)), p.Integer)
+	}
+	return formatValue(p.Min+rng.Float64()*(p.Max-p.Min), p.Integer)
+}
+
+// Override redefine parâmetros do espaço a partir de atribuições "nome=v1,v2,..."
+// (valores da grade) ou "nome=min:max" (intervalo da busca aleatória)
+func (s Space) Override(assignments []string) (Space, error) {
+	space := append(Space(nil), s...)
+	for _, assignment := range assignments {
+		name, spec, ok := strings.Cut(assignment, "=")
+		if !ok || spec == "" {
+			return nil, fmt.Errorf("espaço inválido %q (use nome=v1,v2 ou nome=min:max)", assignment)
+		}
+		index := space.index(strings.TrimSpace(name))
+		if index < 0 {
+			return nil, fmt.Errorf("parâmetro desconhecido: %s (disponíveis: %s)", name, strings.Join(space.Names(), ", "))
+		}
+
+		param := space[index]
+		if low, high, isRange := strings.Cut(spec, ":"); isRange {
+			min, err1 := strconv.ParseFloat(low, 64)
+			max, err2 := strconv.ParseFloat(high, 64)
+			if err1 != nil || err2 != nil || max <= min || (param.Log && min <= 0) {
+				return nil, fmt.Errorf("intervalo inválido para %s: %s", param.Name, spec)
+			}
+			param.Min, param.Max = min, max
+		} else {
+			param.Values = strings.Split(spec, ",")
+			param.Min, param.Max = 0, 0
+		}
+		space[index] = param
+	}
+	return space, nil
+}
+
+// Names retorna os nomes dos parâmetros do espaço
+func (s Space) Names() []string {
+	names := make([]string, len(s))
+	for i, param := range s {
+		names[i] = param.Name
+	}
+	return names
+}
+
+// Has indica se o espaço declara o parâmetro
+func (s Space) Has(name string) bool {
+	return s.index(name) >= 0
+}
+
+// index retorna a posição do parâmetro no espaço (-1 se não existir)
+func (s Space) index(name string) int {
+	for i, param := range s {
+		if param.Name == name {
+			return i
+		}
+	}
+	return -1
+}
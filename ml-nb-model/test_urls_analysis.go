@@ -1,29 +1,31 @@
 package main
 
 import (
+	"bytes"
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/souza/esw-008/ml-nb-model/internal/crawler"
+	"github.com/souza/esw-008/ml-nb-model/internal/dataset"
+	"github.com/souza/esw-008/ml-nb-model/internal/heuristic"
 	"github.com/souza/esw-008/ml-nb-model/internal/mlp"
 	"github.com/souza/esw-008/ml-nb-model/internal/models"
 	"github.com/souza/esw-008/ml-nb-model/internal/naivebayes"
 )
 
-// loadDatasetFromURLTest carrega o dataset de uma URL
+// loadDatasetFromURLTest carrega o dataset de uma URL (usando o cache do crawler)
 func loadDatasetFromURLTest(url string) ([]models.NewsRecord, error) {
-	resp, err := http.Get(url)
+	resp, err := crawler.Fetch(url)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	return parseDatasetTest(resp.Body)
+	return parseDatasetTest(bytes.NewReader(resp.Body))
 }
 
 // parseDatasetTest parseia o dataset CSV
@@ -63,7 +65,7 @@ func parseDatasetTest(r io.Reader) ([]models.NewsRecord, error) {
 }
 
 // analyzeURLTest analisa uma URL específica
-func analyzeURLTest(url string, records []models.NewsRecord) {
+func analyzeURLTest(url string, docs []models.Document) {
 	fmt.Printf("\n" + strings.Repeat("=", 80))
 	fmt.Printf("ANALISANDO: %s\n", url)
 	fmt.Println(strings.Repeat("=", 80))
@@ -85,10 +87,7 @@ func analyzeURLTest(url string, records []models.NewsRecord) {
 	}
 
 	// Verificar heurística
-	lowerText := strings.ToLower(articleText)
-	if strings.Contains(lowerText, "boato") || strings.Contains(lowerText, "falso") ||
-		strings.Contains(lowerText, "mentira") || strings.Contains(lowerText, "desmentido") ||
-		strings.Contains(lowerText, "fake news") {
+	if len(heuristic.Matches(articleText)) > 0 {
 		fmt.Println("🔍 [HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
 		fmt.Println("📋 Classificação: Provavelmente Falsa (por heurística)")
 		return
@@ -98,10 +97,10 @@ func analyzeURLTest(url string, records []models.NewsRecord) {
 	fmt.Println("🤖 Treinando classificadores...")
 
 	mlpClassifier := mlp.NewClassifier(1000, 50, 2)
-	mlpClassifier.Train(records)
+	mlpClassifier.Train(docs)
 
 	nbClassifier := naivebayes.NewClassifier()
-	nbClassifier.TrainNB(records)
+	nbClassifier.TrainNB(docs)
 
 	// Classificar
 	mlpLabel, mlpConfidence, mlpProbs, mlpTokens := mlpClassifier.ClassifyWithDebug(articleText)
@@ -166,7 +165,7 @@ type ResultadoURL struct {
 }
 
 // analyzeURLTestWithReturn analisa uma URL específica e retorna o resultado
-func analyzeURLTestWithReturn(url string, records []models.NewsRecord, nomeNoticia string) ResultadoURL {
+func analyzeURLTestWithReturn(url string, docs []models.Document, nomeNoticia string) ResultadoURL {
 	fmt.Printf("\n" + strings.Repeat("=", 80))
 	fmt.Printf("ANALISANDO: %s\n", url)
 	fmt.Println(strings.Repeat("=", 80))
@@ -196,10 +195,7 @@ func analyzeURLTestWithReturn(url string, records []models.NewsRecord, nomeNotic
 	}
 
 	// Verificar heurística
-	lowerText := strings.ToLower(articleText)
-	if strings.Contains(lowerText, "boato") || strings.Contains(lowerText, "falso") ||
-		strings.Contains(lowerText, "mentira") || strings.Contains(lowerText, "desmentido") ||
-		strings.Contains(lowerText, "fake news") {
+	if len(heuristic.Matches(articleText)) > 0 {
 		fmt.Println("🔍 [HEURÍSTICA] O texto contém termos típicos de desmentido ou fake news.")
 		fmt.Println("📋 Classificação: Provavelmente Falsa (por heurística)")
 		return ResultadoURL{
@@ -213,10 +209,10 @@ func analyzeURLTestWithReturn(url string, records []models.NewsRecord, nomeNotic
 	fmt.Println("🤖 Treinando classificadores...")
 
 	mlpClassifier := mlp.NewClassifier(1000, 50, 2)
-	mlpClassifier.Train(records)
+	mlpClassifier.Train(docs)
 
 	nbClassifier := naivebayes.NewClassifier()
-	nbClassifier.TrainNB(records)
+	nbClassifier.TrainNB(docs)
 
 	// Classificar
 	mlpLabel, mlpConfidence, mlpProbs, mlpTokens := mlpClassifier.ClassifyWithDebug(articleText)
@@ -351,6 +347,11 @@ func printSummaryTable(resultados []ResultadoURL) {
 }
 
 func main() {
+	// Flags de cache (--offline, --cache-dir, --cache-ttl, --no-cache)
+	crawlerOptions := crawler.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	crawler.Configure(*crawlerOptions)
+
 	// URLs para teste
 	urls := []string{
 		"https://g1.globo.com/saude/bem-estar/noticia/2025/07/07/como-fazer-a-higiene-do-sono-veja-quais-sao-os-maiores-inimigos-de-uma-noite-restauradora.ghtml",
@@ -381,6 +382,7 @@ func main() {
 		log.Fatalf("❌ Falha ao carregar o dataset: %v", err)
 	}
 	fmt.Printf("✅ Dataset carregado com %d registros\n", len(records))
+	docs := dataset.FromPairs(records)
 
 	// Slice para armazenar resultados
 	var resultados []ResultadoURL
@@ -388,11 +390,11 @@ func main() {
 	// Analisar cada URL
 	for i, url := range urls {
 		fmt.Printf("\n🔄 Processando URL %d/5...\n", i+1)
-		resultado := analyzeURLTestWithReturn(url, records, nomesNoticias[i])
+		resultado := analyzeURLTestWithReturn(url, docs, nomesNoticias[i])
 		resultados = append(resultados, resultado)
 
 		// Pausa entre análises para não sobrecarregar os servidores
-		if i < len(urls)-1 {
+		if i < len(urls)-1 && !crawlerOptions.Offline {
 			fmt.Println("⏳ Aguardando 3 segundos antes da próxima análise...")
 			time.Sleep(3 * time.Second)
 		}